@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -13,6 +14,7 @@ import (
 	"time"
 
 	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/walk"
 )
 
 // Thresholds for file size buckets
@@ -113,18 +115,15 @@ func main() {
 	case "dashboard":
 		outputDashboard(*dir, *top, *snapshotFile)
 	default:
-		outputSARIF(files, *top)
+		outputSARIF(files, *dir, *top)
 	}
 }
 
-func outputSARIF(files []fileInfo, top int) {
+func outputSARIF(files []fileInfo, dir string, top int) {
 	if top > 0 && len(files) > top {
 		files = files[:top]
 	}
-	log := buildSARIF(files)
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(log); err != nil {
+	if err := writeSARIF(os.Stdout, files, dir); err != nil {
 		fmt.Fprintf(os.Stderr, "error encoding SARIF: %v\n", err)
 		os.Exit(1)
 	}
@@ -450,19 +449,10 @@ func analyzeAll(root string) (*analysisResult, error) {
 	result := &analysisResult{}
 	var mdFiles []string
 
-	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Skip hidden directories and vendor (but not the root ".")
-		if d.IsDir() {
-			name := d.Name()
-			if name != "." && (strings.HasPrefix(name, ".") || name == "vendor" || name == "node_modules") {
-				return filepath.SkipDir
-			}
-			return nil
-		}
+	// Skip hidden directories, vendor, and node_modules on top of ignore
+	// files; hidden files such as .golangci.yml are still analyzed.
+	opts := walk.Options{ExcludeDirs: []string{".*", "vendor", "node_modules"}}
+	err := walk.Walk(root, opts, func(path string, _ os.DirEntry) error {
 
 		// Track markdown files (unless excluded)
 		if strings.HasSuffix(strings.ToLower(path), ".md") {
@@ -561,35 +551,7 @@ func findOrphanMD(mdFiles []string) int {
 // isExcludedMD checks if an MD file path matches any exclude pattern.
 func isExcludedMD(path string) bool {
 	for _, pattern := range excludePatterns {
-		pattern = strings.TrimSpace(pattern)
-		if pattern == "" {
-			continue
-		}
-		// Convert glob pattern to work with filepath.Match
-		// Handle ** patterns by checking if path contains the non-** parts
-		if strings.Contains(pattern, "**") {
-			// Split on ** and check if all parts are present in order
-			parts := strings.Split(pattern, "**")
-			remaining := path
-			matched := true
-			for _, part := range parts {
-				part = strings.Trim(part, "/")
-				if part == "" {
-					continue
-				}
-				idx := strings.Index(remaining, part)
-				if idx == -1 {
-					matched = false
-					break
-				}
-				remaining = remaining[idx+len(part):]
-			}
-			if matched {
-				return true
-			}
-		} else if matched, _ := filepath.Match(pattern, path); matched {
-			return true
-		} else if matched, _ := filepath.Match(pattern, filepath.Base(path)); matched {
+		if walk.MatchGlob(strings.TrimSpace(pattern), path) {
 			return true
 		}
 	}
@@ -611,10 +573,36 @@ func countLines(path string) (int, error) {
 	return count, scanner.Err()
 }
 
-func buildSARIF(files []fileInfo) *sarif.Log {
-	log := sarif.NewLog()
+// sarifRules describes the size tiers reported in SARIF mode.
+var sarifRules = []sarif.ReportingDescriptor{
+	{
+		ID:                   "filesize-red",
+		ShortDescription:     sarif.Text(fmt.Sprintf("Go source file has %d or more lines", ThresholdRed)),
+		FullDescription:      sarif.Text("Very large source files are hard to review and usually mix several responsibilities."),
+		Help:                 sarif.Text("Split the file by responsibility."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "error"},
+	},
+	{
+		ID:                   "filesize-yellow",
+		ShortDescription:     sarif.Text(fmt.Sprintf("Go source file has %d or more lines", ThresholdYellow)),
+		FullDescription:      sarif.Text("The file is approaching the size at which it becomes hard to navigate."),
+		Help:                 sarif.Text("Consider splitting the file before it grows further."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"},
+	},
+}
+
+// writeSARIF streams a result for every red or yellow file to w, with
+// paths relative to dir.
+func writeSARIF(w io.Writer, files []fileInfo, dir string) error {
 	run := sarif.Run{
-		Tool: sarif.Tool{Driver: sarif.Driver{Name: "lintkit-filesize"}},
+		Tool: sarif.Tool{Driver: sarif.Driver{Name: "lintkit-filesize", Rules: sarifRules}},
+	}
+	if err := run.Relativize(dir); err != nil {
+		return err
+	}
+	sw := sarif.NewWriter(w)
+	if err := sw.BeginRun(run); err != nil {
+		return err
 	}
 
 	for _, f := range files {
@@ -632,7 +620,7 @@ func buildSARIF(files []fileInfo) *sarif.Log {
 			continue // Green files don't get reported
 		}
 
-		run.Results = append(run.Results, sarif.Result{
+		err := sw.WriteResult(sarif.Result{
 			RuleID: ruleID,
 			Level:  level,
 			Message: sarif.Message{
@@ -643,9 +631,14 @@ func buildSARIF(files []fileInfo) *sarif.Log {
 					ArtifactLocation: sarif.ArtifactLocation{URI: filepath.ToSlash(f.path)},
 				},
 			}},
-		})
+		}.WithFingerprint())
+		if err != nil {
+			return err
+		}
 	}
 
-	log.Runs = append(log.Runs, run)
-	return log
+	if err := sw.EndRun(); err != nil {
+		return err
+	}
+	return sw.Close()
 }
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/configschema"
+	"github.com/dkoosis/lintkit/pkg/lint/builtin"
+)
+
+const configUsage = "usage: lintkit config validate [--kind KIND] [FILE...] | schema [KIND]"
+
+// runConfig dispatches `lintkit config validate|schema`. It runs before the
+// project config is loaded, so it can report every problem in a broken one.
+func runConfig(args []string, opts globalOptions) error {
+	if len(args) == 0 {
+		return errors.New(configUsage)
+	}
+	switch args[0] {
+	case "validate":
+		return validateConfig(args[1:], opts)
+	case "schema":
+		return printSchema(args[1:])
+	default:
+		return fmt.Errorf("unknown config operation %q\n%s", args[0], configUsage)
+	}
+}
+
+// validateConfig checks config files against their schemas and loads them
+// as the linters would, rejecting rules entries that name no rule as other
+// commands do. Without files it checks the project config and the dbsanity
+// baseline and history files it names.
+func validateConfig(args []string, opts globalOptions) error {
+	fs := flag.NewFlagSet("config validate", flag.ContinueOnError)
+	kindName := fs.String("kind", "project", "Kind of the files: "+kindNames())
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	kind, ok := configschema.Lookup(*kindName)
+	if !ok {
+		return fmt.Errorf("unknown config kind %q (want %s)", *kindName, kindNames())
+	}
+
+	paths := fs.Args()
+	if len(paths) == 0 {
+		if kind.Name != "project" {
+			return fmt.Errorf("config validate --kind %s needs the files to check", kind.Name)
+		}
+		path := opts.config
+		if path == "" {
+			found, err := config.Discover(".")
+			if err != nil {
+				return fmt.Errorf("discover config: %w", err)
+			}
+			if found == "" {
+				return fmt.Errorf("no %s found", config.FileName)
+			}
+			path = found
+		}
+		paths = []string{path}
+	}
+
+	type target struct {
+		kind configschema.Kind
+		path string
+	}
+	var targets []target
+	for _, path := range paths {
+		targets = append(targets, target{kind, path})
+	}
+
+	invalid := 0
+	for i := 0; i < len(targets); i++ {
+		t := targets[i]
+		if err := t.kind.Check(t.path); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			invalid++
+			continue
+		}
+		if t.kind.Name != "project" {
+			continue
+		}
+		cfg, err := config.Load(t.path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			invalid++
+			continue
+		}
+		if err := checkRuleOverrides(cfg, builtin.NewRegistry(cfg)); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", t.path, err)
+			invalid++
+			continue
+		}
+		if cfg.Dbsanity == nil {
+			continue
+		}
+		if cfg.Dbsanity.Baseline != "" {
+			k, _ := configschema.Lookup("dbsanity-baseline")
+			targets = append(targets, target{k, cfg.Resolve(cfg.Dbsanity.Baseline)})
+		}
+		// The history file is written by the first run.
+		if history := cfg.Resolve(cfg.Dbsanity.History); history != "" && fileExists(history) {
+			k, _ := configschema.Lookup("dbsanity-history")
+			targets = append(targets, target{k, history})
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d config file(s) invalid", invalid, len(targets))
+	}
+	fmt.Fprintf(os.Stderr, "config: %d file(s) valid\n", len(targets))
+	return nil
+}
+
+// printSchema writes the JSON Schema of a config kind, or lists the kinds.
+func printSchema(args []string) error {
+	fs := flag.NewFlagSet("config schema", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	switch fs.NArg() {
+	case 0:
+		for _, k := range configschema.Kinds() {
+			fmt.Printf("%-18s %s\n", k.Name, k.Description)
+		}
+		return nil
+	case 1:
+		kind, ok := configschema.Lookup(fs.Arg(0))
+		if !ok {
+			return fmt.Errorf("unknown config kind %q (want %s)", fs.Arg(0), kindNames())
+		}
+		_, err := os.Stdout.Write(kind.Schema())
+		return err
+	default:
+		return errors.New(configUsage)
+	}
+}
+
+func kindNames() string {
+	var names []string
+	for _, k := range configschema.Kinds() {
+		names = append(names, k.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dkoosis/lintkit/pkg/baseline"
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/fix"
+)
+
+// runFix runs linters like `lintkit run` and applies the fixes attached to
+// their active findings, printing the changes as a unified diff. With
+// --dry-run nothing is written.
+func runFix(args []string, opts globalOptions) error {
+	fs := flag.NewFlagSet("fix", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "Print the diff without changing any file")
+	//nolint:errcheck // CLI usage output
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: lintkit fix [--dry-run] [LINTER [ARGS...] [-- LINTER [ARGS...]]...]\n")
+		fmt.Fprintf(fs.Output(), "With no linters given, every linter configured in %s runs.\n", config.FileName)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	log, err := runAll(fs.Args())
+	if err != nil {
+		return err
+	}
+	if log == nil {
+		return errors.New("linters produced no SARIF log")
+	}
+	postprocess(log)
+	if opts.baseline != "" {
+		b, err := baseline.Load(opts.baseline)
+		if err != nil {
+			return fmt.Errorf("load baseline: %w", err)
+		}
+		b.Apply(log)
+	}
+
+	plan, err := fix.NewPlan(log)
+	if err != nil {
+		return err
+	}
+	if err := plan.WriteDiff(os.Stdout); err != nil {
+		return err
+	}
+	for _, s := range plan.Skipped {
+		fmt.Fprintf(os.Stderr, "fix: skipped %s\n", s)
+	}
+
+	verb := "would apply"
+	if !*dryRun {
+		if err := plan.Apply(); err != nil {
+			return err
+		}
+		verb = "applied"
+	}
+	fmt.Fprintf(os.Stderr, "fix: %s %d fix(es) to %d file(s)\n", verb, len(plan.Applied), len(plan.Edits))
+	return nil
+}
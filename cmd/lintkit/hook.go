@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/git"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/lint/builtin"
+)
+
+// hookMarker identifies pre-commit scripts written by `lintkit hook
+// install`, so uninstall never removes a hook it did not write.
+const hookMarker = "# lintkit pre-commit hook"
+
+const hookUsage = "usage: lintkit hook install [--force] [--fail-on LEVEL] [LINTER [ARGS...] [-- ...]] | uninstall | run [--fail-on LEVEL] [LINTER [ARGS...] [-- ...]]"
+
+// runHook dispatches `lintkit hook install|uninstall|run`.
+func runHook(args []string, opts globalOptions) error {
+	if len(args) == 0 {
+		return errors.New(hookUsage)
+	}
+	switch args[0] {
+	case "install":
+		return installHook(args[1:], opts)
+	case "uninstall":
+		return uninstallHook()
+	case "run":
+		return runPreCommit(args[1:], opts)
+	default:
+		return fmt.Errorf("unknown hook operation %q\n%s", args[0], hookUsage)
+	}
+}
+
+// installHook writes a pre-commit script that calls `lintkit hook run` with
+// the given fail-on level and linter invocations.
+func installHook(args []string, opts globalOptions) error {
+	fs := flag.NewFlagSet("hook install", flag.ContinueOnError)
+	force := fs.Bool("force", false, "Replace an existing pre-commit hook not written by lintkit")
+	failOn := fs.String("fail-on", "", "Lowest result level that blocks the commit (default: the project's fail_on, else error)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *failOn != "" {
+		if _, err := lint.ParseFailOn(*failOn); err != nil {
+			return err
+		}
+	}
+
+	dir, err := git.HooksDir(context.Background(), ".")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, "pre-commit")
+	if existing, err := os.ReadFile(path); err == nil && !bytes.Contains(existing, []byte(hookMarker)) && !*force {
+		return fmt.Errorf("%s exists and was not written by lintkit; use --force to replace it", path)
+	}
+
+	command := []string{`"${LINTKIT:-lintkit}"`}
+	if opts.config != "" {
+		abs, err := filepath.Abs(opts.config)
+		if err != nil {
+			return err
+		}
+		command = append(command, "--config", shellQuote(abs))
+	}
+	command = append(command, "hook", "run")
+	if *failOn != "" {
+		command = append(command, "--fail-on", shellQuote(*failOn))
+	}
+	for _, a := range fs.Args() {
+		command = append(command, shellQuote(a))
+	}
+
+	script := "#!/bin/sh\n" + hookMarker + "; remove with `lintkit hook uninstall`.\n" +
+		"# Set LINTKIT to the lintkit binary when it is not on PATH.\n" +
+		"exec " + strings.Join(command, " ") + "\n"
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil { //nolint:gosec // hooks must be executable
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "hook: installed %s\n", path)
+	return nil
+}
+
+// uninstallHook removes the pre-commit script if lintkit wrote it.
+func uninstallHook() error {
+	dir, err := git.HooksDir(context.Background(), ".")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, "pre-commit")
+	existing, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !bytes.Contains(existing, []byte(hookMarker)) {
+		return fmt.Errorf("%s was not written by lintkit; leaving it in place", path)
+	}
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "hook: removed %s\n", path)
+	return nil
+}
+
+// runPreCommit lints the content staged for commit. The index is exported
+// to a temporary directory so that unstaged edits neither hide nor cause
+// findings; the linters run there as they would with `lintkit run`,
+// restricted to the staged files, and findings are printed as text.
+func runPreCommit(args []string, opts globalOptions) error {
+	fs := flag.NewFlagSet("hook run", flag.ContinueOnError)
+	failOn := fs.String("fail-on", opts.failOn, "Lowest result level that blocks the commit: error, warning, note, or none")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	staged, err := git.Staged(ctx, ".")
+	if err != nil {
+		return err
+	}
+	if staged.Len() == 0 {
+		return nil
+	}
+
+	top, err := git.TopLevel(ctx, ".")
+	if err != nil {
+		return err
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	realWD, err := filepath.EvalSymlinks(wd)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(top, realWD)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := os.MkdirTemp("", "lintkit-hook-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(snapshot) }()
+	if err := git.CheckoutIndex(ctx, ".", snapshot); err != nil {
+		return err
+	}
+
+	// Paths given before the switch still name the caller's files.
+	for _, p := range []*string{&opts.baseline, &opts.config} {
+		if *p != "" {
+			if *p, err = filepath.Abs(*p); err != nil {
+				return err
+			}
+		}
+	}
+
+	base := filepath.Join(snapshot, rel)
+	if err := os.MkdirAll(base, 0o755); err != nil {
+		return err
+	}
+	if err := os.Chdir(base); err != nil {
+		return err
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	// Lint with the staged project config.
+	project = &config.Config{}
+	if err := loadProject(opts.config); err != nil {
+		return err
+	}
+	// A project that configures no linters has nothing to check; the hook
+	// must not block its commits.
+	if fs.NArg() == 0 && len(project.Configured()) == 0 {
+		fmt.Fprintln(os.Stderr, "hook: no linters configured; skipping")
+		return nil
+	}
+	registry = builtin.NewRegistry(project)
+	changed = staged.Rebase(top, snapshot)
+	srcRoot = snapshot
+
+	opts.failOn = *failOn
+	opts.format = "text"
+	policy, err := failOnPolicy(opts)
+	if err != nil {
+		return err
+	}
+	return execute(runCommand, fs.Args(), opts, policy)
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
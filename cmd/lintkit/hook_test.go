@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+)
+
+func TestRunPreCommitWithoutConfiguredLinters(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	saved, savedRoot := project, srcRoot
+	t.Cleanup(func() { project, srcRoot = saved, savedRoot })
+
+	for name, cfg := range map[string]string{
+		"no config":      "",
+		"no linters set": "fail_on: warning\n",
+	} {
+		dir := t.TempDir()
+		gitCmd(t, dir, "init", "-q")
+		writeFile(t, filepath.Join(dir, "a.md"), "a\n")
+		if cfg != "" {
+			writeFile(t, filepath.Join(dir, config.FileName), cfg)
+		}
+		gitCmd(t, dir, "add", ".")
+		t.Chdir(dir)
+
+		if err := runPreCommit(nil, globalOptions{}); err != nil {
+			t.Fatalf("%s: runPreCommit: %v", name, err)
+		}
+	}
+}
+
+func gitCmd(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
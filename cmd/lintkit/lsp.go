@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dkoosis/lintkit/pkg/lsp"
+)
+
+// runLSP serves diagnostics to an editor over stdin and stdout. The wiki
+// and nugget directories come from the wikifmt and nuglint sections of the
+// project config, defaulting to the workspace root and .orca/kg.
+func runLSP(args []string) error {
+	fs := flag.NewFlagSet("lsp", flag.ContinueOnError)
+	//nolint:errcheck // CLI usage output
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: lintkit lsp\nServes wikifmt and nuglint diagnostics over the Language Server Protocol on stdio.\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var opts lsp.Options
+	opts.Walk = project.WalkOptions()
+	opts.Rules = project.Rules
+	var err error
+	if sec := project.Wikifmt; sec != nil {
+		if opts.WikiRoots, err = absPaths(project.PathsOr(sec.Paths)); err != nil {
+			return err
+		}
+	}
+	if sec := project.Nuglint; sec != nil {
+		if opts.NuggetRoots, err = absPaths(project.PathsOr(sec.Paths)); err != nil {
+			return err
+		}
+	}
+	return lsp.NewServer(opts).Serve(context.Background(), os.Stdin, os.Stdout)
+}
+
+func absPaths(paths []string) ([]string, error) {
+	out := make([]string, 0, len(paths))
+	for _, p := range paths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, abs)
+	}
+	return out, nil
+}
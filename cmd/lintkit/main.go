@@ -3,450 +3,526 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
-	"time"
-
-	"github.com/dkoosis/lintkit/pkg/dbsanity"
-	"github.com/dkoosis/lintkit/pkg/dbschema"
-	"github.com/dkoosis/lintkit/pkg/docsprawl"
-	"github.com/dkoosis/lintkit/pkg/filesize"
-	"github.com/dkoosis/lintkit/pkg/jsonl"
-	"github.com/dkoosis/lintkit/pkg/nobackups"
-	"github.com/dkoosis/lintkit/pkg/nuglint"
+	"strings"
+
+	"github.com/dkoosis/lintkit/pkg/baseline"
+	"github.com/dkoosis/lintkit/pkg/catalog"
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/format"
+	"github.com/dkoosis/lintkit/pkg/git"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/lint/builtin"
 	"github.com/dkoosis/lintkit/pkg/sarif"
-	"github.com/dkoosis/lintkit/pkg/stale"
-	"github.com/dkoosis/lintkit/pkg/wikifmt"
+	"github.com/dkoosis/lintkit/pkg/suppress"
 )
 
-func main() {
-	if len(os.Args) < 2 {
-		usage()
-		os.Exit(1)
-	}
-
-	subcommand := os.Args[1]
-	switch subcommand {
-	case "docsprawl":
-		fs := docsprawl.Command()
-		if err := docsprawl.RunCLI(fs, os.Args[2:], os.Stdout); err != nil {
-			fmt.Fprintln(os.Stderr, "error:", err)
-			os.Exit(1)
-		}
-	case "dbsanity":
-		if err := runDbSanity(os.Args[2:]); err != nil {
-			fmt.Fprintf(os.Stderr, "%v\n", err)
-			os.Exit(1)
-		}
-	case "wikifmt":
-		if err := runWikifmt(os.Args[2:]); err != nil {
-			fmt.Fprintf(os.Stderr, "error: %v\n", err)
-			os.Exit(1)
-		}
-	case "stale":
-		if err := runStale(os.Args[2:]); err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(1)
-		}
-	case "nuglint":
-		runNuglint(os.Args[2:])
-	case "filesize":
-		if err := runFilesize(os.Args[2:]); err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(1)
-		}
-	case "nobackups":
-		if err := runNoBackups(os.Args[2:]); err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(1)
-		}
-	case "jsonl":
-		if err := runJSONL(os.Args[2:]); err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(1)
-		}
-	case "dbschema":
-		if err := runDbSchema(os.Args[2:]); err != nil {
-			fmt.Fprintln(os.Stderr, "error:", err)
-			os.Exit(1)
-		}
-	case "help", "-h", "--help":
-		usage()
-	default:
-		fmt.Fprintf(os.Stderr, "unknown command: %s\n", subcommand)
-		usage()
-		os.Exit(1)
-	}
+// command is a lintkit subcommand that produces a SARIF log.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) (*sarif.Log, error)
+	// stream, when set, writes the log as results are found instead; see
+	// executeStream.
+	stream func(args []string, w *sarif.Writer, keep func(*sarif.Run, *sarif.Result) bool) (sarif.Invocation, error)
 }
 
-//nolint:errcheck // CLI usage output - errors are intentionally ignored
-func usage() {
-	fmt.Fprintln(flag.CommandLine.Output(), "Usage: lintkit <command> [options]")
-	fmt.Fprintln(flag.CommandLine.Output(), "Commands:")
-	fmt.Fprintln(flag.CommandLine.Output(), "  docsprawl    Analyze markdown sprawl and emit SARIF")
-	fmt.Fprintln(flag.CommandLine.Output(), "  dbsanity     Check SQLite row counts against baseline")
-	fmt.Fprintln(flag.CommandLine.Output(), "  wikifmt      Check wiki-style markdown files")
-	fmt.Fprintln(flag.CommandLine.Output(), "  stale        Detect stale artifacts based on mtime rules")
-	fmt.Fprintln(flag.CommandLine.Output(), "  nuglint      Lint ORCA knowledge nugget JSONL files")
-	fmt.Fprintln(flag.CommandLine.Output(), "  filesize     Check file sizes against budget rules")
-	fmt.Fprintln(flag.CommandLine.Output(), "  nobackups    Detect backup/temporary files")
-	fmt.Fprintln(flag.CommandLine.Output(), "  jsonl        Validate JSONL files against JSON Schema")
-	fmt.Fprintln(flag.CommandLine.Output(), "  dbschema     Compare SQLite schemas against expected DDL")
+// registry holds the bundled linters, configured from the project file.
+var registry = builtin.NewRegistry(nil)
+
+// globalOptions are flags accepted before the subcommand name.
+type globalOptions struct {
+	baseline     string
+	changedSince string
+	config       string
+	failOn       string
+	format       string
+	staged       bool
 }
 
-func runDbSanity(args []string) error {
-	fs := flag.NewFlagSet("dbsanity", flag.ExitOnError)
-	baselinePath := fs.String("baseline", "", "Path to baseline JSON with expected table counts")
-	threshold := fs.Float64("threshold", 20, "Percentage threshold for drift detection")
-	configPath := fs.String("config", "", "Path to YAML config for data checks")
-	historyPath := fs.String("history", "", "Path to history JSON file for WoW tracking")
-	updateHistory := fs.Bool("update", false, "Update history file with current results")
+// project is the loaded .lintkit.yml; it is empty when no file exists.
+// Subcommands read their defaults from it and let flags override them.
+var project = &config.Config{}
 
-	//nolint:errcheck // CLI usage output
-	fs.Usage = func() {
-		fmt.Fprintf(fs.Output(), "Usage: lintkit dbsanity [--baseline counts.json | --config checks.yaml] DB...\n")
-		fmt.Fprintf(fs.Output(), "\nModes:\n")
-		fmt.Fprintf(fs.Output(), "  Legacy:  --baseline counts.json [--threshold PCT]\n")
-		fmt.Fprintf(fs.Output(), "  Checks:  --config checks.yaml [--history history.json] [--update]\n")
-		fs.PrintDefaults()
-	}
+// changed is the set of files selected by --changed-since or --staged; nil
+// means every file is linted.
+var changed *git.Changes
 
-	if err := fs.Parse(args); err != nil {
-		return err
-	}
+// srcRoot is the source root artifact URIs are reported relative to: the
+// git work tree, else the project config directory.
+var srcRoot = "."
 
-	dbPaths := fs.Args()
-	if len(dbPaths) == 0 {
-		fs.Usage()
-		return fmt.Errorf("at least one database path is required")
-	}
+// Exit codes. Findings and failures are kept distinct so CI can tell a
+// dirty tree from a broken run.
+const (
+	exitFindings = 1 // active results at or above the fail-on level
+	exitFailure  = 2 // usage error, or a linter or lintkit itself failed
+)
 
-	// Config-based mode
-	if *configPath != "" {
-		return runDbSanityChecks(dbPaths, *configPath, *historyPath, *updateHistory)
-	}
+// findingsError reports that results at or above the fail-on level remain
+// after exclusion and baseline filtering.
+type findingsError struct {
+	count int
+	level lint.FailOn
+}
 
-	// Legacy baseline mode
-	if *baselinePath == "" {
-		fs.Usage()
-		return fmt.Errorf("either --baseline or --config is required")
-	}
+func (e findingsError) Error() string {
+	return fmt.Sprintf("%d finding(s) at or above level %s", e.count, e.level)
+}
 
-	baseline, err := dbsanity.LoadBaseline(*baselinePath)
+func main() {
+	opts, args, err := parseGlobalFlags(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 	if err != nil {
-		return fmt.Errorf("failed to load baseline: %w", err)
+		exit(err)
+	}
+	if _, err := format.Lookup(opts.format); err != nil {
+		exit(err)
+	}
+
+	if len(args) < 1 {
+		usage()
+		os.Exit(exitFailure)
 	}
 
-	var totalFindings []sarif.Result
-	for _, dbPath := range dbPaths {
-		results, err := dbsanity.CheckDatabase(context.Background(), dbPath, baseline, *threshold)
+	// These commands lint nothing and run before the project config loads:
+	// config so that it can report every problem in a broken one, help and
+	// sarif so that a broken one does not stop them. sarif diff therefore
+	// fails on the --fail-on level alone.
+	switch args[0] {
+	case "help", "-h", "--help":
+		usage()
+		return
+	case "config":
+		if err := runConfig(args[1:], opts); err != nil && !errors.Is(err, flag.ErrHelp) {
+			exit(err)
+		}
+		return
+	case "sarif":
+		policy, err := failOnPolicy(opts)
 		if err != nil {
-			return fmt.Errorf("checking %s: %w", dbPath, err)
+			exit(err)
 		}
-		totalFindings = append(totalFindings, results...)
+		if err := runSARIF(args[1:], opts, policy); err != nil {
+			exit(err)
+		}
+		return
 	}
 
-	log := dbsanity.BuildLog(totalFindings)
-
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(log); err != nil {
-		return err
+	if err := loadProject(opts.config); err != nil {
+		exit(err)
 	}
-
-	if len(totalFindings) > 0 {
-		return fmt.Errorf("dbsanity detected drift in %d table(s)", len(totalFindings))
+	registry = builtin.NewRegistry(project)
+	if err := checkRuleOverrides(project, registry); err != nil {
+		exit(fmt.Errorf("%s: %w", config.FileName, err))
 	}
+	srcRoot = sourceRoot()
 
-	return nil
-}
+	if err := resolveChanged(opts); err != nil {
+		exit(err)
+	}
 
-func runDbSanityChecks(dbPaths []string, configPath, historyPath string, updateHistory bool) error {
-	cfg, err := dbsanity.LoadConfig(configPath)
+	policy, err := failOnPolicy(opts)
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		exit(err)
 	}
 
-	var history dbsanity.History
-	if historyPath != "" {
-		history, err = dbsanity.LoadHistory(historyPath)
-		if err != nil {
-			return fmt.Errorf("load history: %w", err)
+	subcommand := args[0]
+	switch subcommand {
+	case "baseline":
+		if err := runBaseline(args[1:]); err != nil {
+			exit(err)
 		}
-	}
-
-	now := time.Now()
-	currentWeek := dbsanity.ISOWeek(now)
-
-	var allResults []sarif.Result
-	allCheckResults := make(map[string]dbsanity.CheckResult)
-
-	for _, dbPath := range dbPaths {
-		checkResults, err := dbsanity.RunChecks(context.Background(), dbPath, cfg)
-		if err != nil {
-			return fmt.Errorf("checks on %s: %w", dbPath, err)
+		return
+	case "hook":
+		if err := runHook(args[1:], opts); err != nil && !errors.Is(err, flag.ErrHelp) {
+			exit(err)
 		}
-
-		for k, v := range checkResults {
-			allCheckResults[k] = v
+		return
+	case "lsp":
+		if err := runLSP(args[1:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+			exit(err)
 		}
-
-		results := dbsanity.CompareWithHistory(dbPath, checkResults, &history, currentWeek)
-		allResults = append(allResults, results...)
-	}
-
-	// Update history if requested
-	if updateHistory && historyPath != "" {
-		snapshot := dbsanity.Snapshot{
-			Timestamp: now,
-			Week:      currentWeek,
-			Results:   allCheckResults,
+		return
+	case "mcp":
+		if err := runMCP(args[1:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+			exit(err)
+		}
+		return
+	case "rules":
+		if err := runRules(args[1:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+			exit(err)
 		}
-		history.AddSnapshot(snapshot)
-		if err := dbsanity.SaveHistory(historyPath, history); err != nil {
-			return fmt.Errorf("save history: %w", err)
+		return
+	case "explain":
+		if err := runExplain(args[1:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+			exit(err)
 		}
+		return
+	case "fix":
+		if err := runFix(args[1:], opts); err != nil && !errors.Is(err, flag.ErrHelp) {
+			exit(err)
+		}
+		return
+	case "watch":
+		if err := runWatch(args[1:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+			exit(err)
+		}
+		return
+	case "report":
+		if err := runReport(args[1:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+			exit(err)
+		}
+		return
 	}
 
-	log := dbsanity.BuildLog(allResults)
-
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(log)
-}
-
-func runWikifmt(args []string) error {
-	if len(args) == 0 {
-		fmt.Fprintln(os.Stderr, "lintkit wikifmt requires at least one ROOT directory")
-		return fmt.Errorf("no ROOT directories provided")
+	cmd, ok := lookupCommand(subcommand)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n", subcommand)
+		usage()
+		os.Exit(exitFailure)
 	}
 
-	log, err := wikifmt.Run(args)
-	if err != nil {
-		return err
+	if err := execute(cmd, args[1:], opts, policy); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		exit(err)
 	}
+}
 
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(log); err != nil {
-		return fmt.Errorf("failed to encode SARIF: %w", err)
+// exit reports err and terminates with the exit code matching its kind.
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, "error:", err)
+	var findings findingsError
+	if errors.As(err, &findings) {
+		os.Exit(exitFindings)
 	}
+	os.Exit(exitFailure)
+}
 
-	return nil
+// failOnPolicy returns the --fail-on policy, falling back to the project
+// config and then to lint.DefaultFailOn.
+func failOnPolicy(opts globalOptions) (lint.FailOn, error) {
+	if opts.failOn != "" {
+		return lint.ParseFailOn(opts.failOn)
+	}
+	return lint.ParseFailOn(project.FailOn)
 }
 
-func runStale(args []string) error {
-	fs := flag.NewFlagSet("stale", flag.ContinueOnError)
-	rulesFile := fs.String("rules", "", "Path to the staleness rules file")
+func parseGlobalFlags(args []string) (globalOptions, []string, error) {
+	var opts globalOptions
+	fs := flag.NewFlagSet("lintkit", flag.ContinueOnError)
+	fs.StringVar(&opts.baseline, "baseline", "", "Path to a lintkit baseline file; matching findings are suppressed")
+	fs.StringVar(&opts.config, "config", "", "Path to the project config (default: nearest "+config.FileName+")")
+	fs.StringVar(&opts.format, "format", format.Default, "Output format: "+strings.Join(format.Names(), ", "))
+	fs.StringVar(&opts.failOn, "fail-on", "", "Lowest result level that fails the run: error, warning, note, or none (default: error)")
+	fs.StringVar(&opts.changedSince, "changed-since", "", "Only lint files changed since the git REF")
+	fs.BoolVar(&opts.staged, "staged", false, "Only lint files staged in the git index")
+	fs.Usage = usage
 	if err := fs.Parse(args); err != nil {
-		return err
+		return opts, nil, err
 	}
+	return opts, fs.Args(), nil
+}
 
-	if *rulesFile == "" {
-		return fmt.Errorf("--rules is required")
+// resolveChanged asks git for the files selected by --changed-since or
+// --staged.
+func resolveChanged(opts globalOptions) error {
+	var err error
+	switch {
+	case opts.changedSince != "" && opts.staged:
+		return errors.New("--changed-since and --staged are mutually exclusive")
+	case opts.changedSince != "":
+		changed, err = git.ChangedSince(context.Background(), ".", opts.changedSince)
+	case opts.staged:
+		changed, err = git.Staged(context.Background(), ".")
+	}
+	return err
+}
+
+// sourceRoot returns the top level of the git work tree containing the
+// working directory, falling back to the project config directory.
+func sourceRoot() string {
+	if top, err := git.TopLevel(context.Background(), "."); err == nil {
+		return top
 	}
+	return project.Dir()
+}
 
-	paths := fs.Args()
-	if len(paths) == 0 {
-		paths = []string{"."}
+// loadProject loads the explicit config path, or the nearest .lintkit.yml
+// above the working directory when none is given.
+func loadProject(path string) error {
+	if path == "" {
+		found, err := config.Discover(".")
+		if err != nil {
+			return fmt.Errorf("discover config: %w", err)
+		}
+		if found == "" {
+			return nil
+		}
+		path = found
 	}
 
-	cfg, err := stale.LoadConfig(*rulesFile)
+	cfg, err := config.Load(path)
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
+	project = cfg
+	return nil
+}
 
-	log := sarif.NewLog()
-	run := sarif.Run{
-		Tool: sarif.Tool{Driver: sarif.Driver{Name: "lintkit-stale"}},
-	}
+// postprocess applies the project-wide handling every log gets before it
+// is reported: global excludes, rule overrides, and inline suppressions.
+func postprocess(log *sarif.Log) {
+	applyExcludes(log)
+	sarif.Overrides(project.Rules).Apply(log)
+	suppress.Apply(log, ".")
+}
 
-	for _, root := range paths {
-		results, err := stale.Evaluate(root, cfg)
-		if err != nil {
-			return err
+// checkRuleOverrides rejects rules entries in cfg that name no rule of
+// reg's linters, so a typo does not silently keep a rule enabled.
+func checkRuleOverrides(cfg *config.Config, reg *lint.Registry) error {
+	if len(cfg.Rules) == 0 {
+		return nil
+	}
+	entries := catalog.Build(reg)
+	for id := range cfg.Rules {
+		if _, ok := catalog.Find(entries, id); !ok {
+			return fmt.Errorf("rules: unknown rule %q (run lintkit rules to list them)", id)
 		}
-		run.Results = append(run.Results, results...)
 	}
-
-	log.Runs = append(log.Runs, run)
-
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(log)
+	return nil
 }
 
-func runNuglint(args []string) {
-	if len(args) == 0 {
-		fmt.Fprintln(os.Stderr, "nuglint requires at least one path")
-		os.Exit(1)
+// applyExcludes drops results whose artifact matches a global exclude
+// pattern from the project config.
+func applyExcludes(log *sarif.Log) {
+	if len(project.Exclude) == 0 {
+		return
+	}
+	for i := range log.Runs {
+		run := &log.Runs[i]
+		kept := run.Results[:0]
+		for _, r := range run.Results {
+			if !project.Excluded(run.PrimaryPath(r)) {
+				kept = append(kept, r)
+			}
+		}
+		run.Results = kept
 	}
+}
 
-	results, err := nuglint.Run(args)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+// lookupCommand resolves a SARIF-producing subcommand: `run` or any
+// registered linter.
+func lookupCommand(name string) (command, bool) {
+	if name == runCommand.name {
+		return runCommand, true
 	}
-
-	log := sarif.NewLog()
-	log.Runs = append(log.Runs, sarif.Run{
-		Tool:    sarif.Tool{Driver: sarif.Driver{Name: "lintkit-nuglint"}},
-		Results: results,
-	})
-
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(log); err != nil {
-		fmt.Fprintf(os.Stderr, "failed to write SARIF: %v\n", err)
-		os.Exit(1)
+	l, ok := registry.New(name)
+	if !ok {
+		return command{}, false
 	}
-}
-
-func runFilesize(args []string) error {
-	fs := flag.NewFlagSet("filesize", flag.ContinueOnError)
-	rulesPath := fs.String("rules", "", "Path to YAML rules file")
-
-	if err := fs.Parse(args); err != nil {
-		return err
+	cmd := command{
+		name:    name,
+		summary: registry.Summary(name),
+		run:     func(args []string) (*sarif.Log, error) { return runLinter(name, args) },
 	}
-
-	if *rulesPath == "" {
-		return fmt.Errorf("--rules is required")
+	if _, ok := l.(lint.Streamer); ok {
+		cmd.stream = func(args []string, w *sarif.Writer, keep func(*sarif.Run, *sarif.Result) bool) (sarif.Invocation, error) {
+			return streamLinter(name, args, w, keep)
+		}
 	}
+	return cmd, true
+}
 
-	analyzerRules, err := filesize.LoadRules(*rulesPath)
+// runLinter parses a linter's flags and runs it over the remaining
+// arguments.
+func runLinter(name string, args []string) (*sarif.Log, error) {
+	l, req, err := prepareLinter(name, args)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return lint.RunLog(context.Background(), l, req)
+}
 
-	analyzer := filesize.NewAnalyzer(analyzerRules)
-	log, err := analyzer.Analyze(fs.Args())
+// streamLinter parses a linter's flags and streams its run over the
+// remaining arguments to w; see lint.StreamLog.
+func streamLinter(name string, args []string, w *sarif.Writer, keep func(*sarif.Run, *sarif.Result) bool) (sarif.Invocation, error) {
+	l, req, err := prepareLinter(name, args)
 	if err != nil {
-		return err
+		return sarif.Invocation{}, err
 	}
-
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(log)
+	return lint.StreamLog(context.Background(), l, req, w, keep)
 }
 
-func runNoBackups(paths []string) error {
-	if len(paths) == 0 {
-		paths = []string{"."}
+// prepareLinter creates a linter, parses its flags, and builds the request
+// for the remaining arguments.
+func prepareLinter(name string, args []string) (lint.Linter, lint.Request, error) {
+	l, ok := registry.New(name)
+	if !ok {
+		return nil, lint.Request{}, fmt.Errorf("unknown linter: %s", name)
 	}
 
-	log, err := nobackups.Scan(paths)
-	if err != nil {
-		return err
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	if b, ok := l.(lint.FlagBinder); ok {
+		b.BindFlags(fs)
 	}
-
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(log)
-}
-
-func runJSONL(args []string) error {
-	fs := flag.NewFlagSet("jsonl", flag.ContinueOnError)
-	schemaPath := fs.String("schema", "", "path to JSON Schema file")
-	fs.SetOutput(os.Stderr)
-
 	if err := fs.Parse(args); err != nil {
-		return err
+		return nil, lint.Request{}, err
 	}
 
-	if *schemaPath == "" {
-		return errors.New("--schema is required")
+	req := lint.Request{Paths: fs.Args(), Root: srcRoot}
+	if changed != nil {
+		req.Changed = changed.Contains
 	}
+	return l, req, nil
+}
 
-	files := fs.Args()
-	if len(files) == 0 {
-		return errors.New("at least one JSONL path is required")
+// execute runs a subcommand, applies global post-processing, and writes the
+// log to stdout in the requested format. A log returned alongside an error is still written.
+// It returns a findingsError when results at or above the policy level
+// remain.
+func execute(cmd command, args []string, opts globalOptions, policy lint.FailOn) error {
+	if cmd.stream != nil && opts.format == format.Default && opts.baseline == "" {
+		return executeStream(cmd, args, policy)
 	}
 
-	validator, err := jsonl.NewValidator(*schemaPath)
-	if err != nil {
-		return err
+	log, runErr := cmd.run(args)
+	if log == nil {
+		return runErr
 	}
 
-	log := sarif.NewLog()
-	run := sarif.Run{Tool: sarif.Tool{Driver: sarif.Driver{Name: "lintkit-jsonl"}}}
+	postprocess(log)
 
-	for _, path := range files {
-		results, err := jsonl.ValidateFile(path, validator)
+	if opts.baseline != "" {
+		b, err := baseline.Load(opts.baseline)
 		if err != nil {
-			return err
+			return fmt.Errorf("load baseline: %w", err)
 		}
-		run.Results = append(run.Results, results...)
+		b.Apply(log)
+		dropUnchangedAbsent(log)
 	}
 
-	log.Runs = append(log.Runs, run)
-
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(log); err != nil {
-		return err
+	result, code := runErr, exitFailure
+	if runErr == nil {
+		result, code = nil, 0
+		if n := policy.Count(log); n > 0 {
+			result, code = findingsError{count: n, level: policy}, exitFindings
+		}
 	}
+	sarif.SetCommandLine(log, commandLine(os.Args))
+	sarif.SetExitCode(log, code)
 
-	if len(run.Results) > 0 {
-		return errors.New("validation errors detected")
+	if err := format.Write(os.Stdout, opts.format, log); err != nil {
+		return fmt.Errorf("failed to write %s output: %w", opts.format, err)
 	}
-
-	return nil
+	return result
 }
 
-func runDbSchema(args []string) error {
-	fs := flag.NewFlagSet("dbschema", flag.ExitOnError)
-	expectedPath := fs.String("expected", "", "Path to expected schema DDL file")
-	//nolint:errcheck // CLI usage output
-	fs.Usage = func() {
-		fmt.Fprintf(fs.Output(), "Usage: lintkit dbschema --expected schema.sql DB...\n")
-		fs.PrintDefaults()
+// commandLine joins args into a command line a POSIX shell would split
+// back into them.
+func commandLine(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = a
+		if a == "" || strings.ContainsFunc(a, func(r rune) bool { return !strings.ContainsRune(shellSafe, r) }) {
+			quoted[i] = shellQuote(a)
+		}
 	}
+	return strings.Join(quoted, " ")
+}
 
-	if err := fs.Parse(args); err != nil {
-		return err
+// shellSafe lists the characters that need no quoting in a shell word.
+const shellSafe = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-./:=,@%+"
+
+// dropUnchangedAbsent removes absent baseline entries for files outside the
+// --changed-since or --staged set: they were not linted, not fixed.
+func dropUnchangedAbsent(log *sarif.Log) {
+	if changed == nil {
+		return
+	}
+	for i := range log.Runs {
+		run := &log.Runs[i]
+		kept := run.Results[:0]
+		for _, r := range run.Results {
+			if r.BaselineState != "absent" || changed.Contains(run.PrimaryPath(r)) {
+				kept = append(kept, r)
+			}
+		}
+		run.Results = kept
 	}
+}
 
-	if *expectedPath == "" {
-		return fmt.Errorf("--expected is required")
+//nolint:errcheck // CLI usage output - errors are intentionally ignored
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintln(out, "Usage: lintkit [global options] <command> [options]")
+	fmt.Fprintln(out, "Commands:")
+	for _, name := range registry.Names() {
+		fmt.Fprintf(out, "  %-12s %s\n", name, registry.Summary(name))
+	}
+	fmt.Fprintf(out, "  %-12s %s\n", runCommand.name, runCommand.summary)
+	fmt.Fprintf(out, "  %-12s %s\n", "watch", "Re-run linters as files change and print new and resolved findings")
+	fmt.Fprintf(out, "  %-12s %s\n", "rules", "List every rule with its linter, default level, and description")
+	fmt.Fprintf(out, "  %-12s %s\n", "explain", "Explain a rule with its rationale, examples, and configuration")
+	fmt.Fprintf(out, "  %-12s %s\n", "fix", "Apply the fixes attached to findings and print a unified diff")
+	fmt.Fprintf(out, "  %-12s %s\n", "baseline", "Record current findings as accepted")
+	fmt.Fprintf(out, "  %-12s %s\n", "config", "Validate config files or print their JSON Schemas")
+	fmt.Fprintf(out, "  %-12s %s\n", "sarif", "Merge, filter, diff, or summarize SARIF files")
+	fmt.Fprintf(out, "  %-12s %s\n", "report", "Render SARIF files as a self-contained HTML report")
+	fmt.Fprintf(out, "  %-12s %s\n", "hook", "Install, uninstall, or run the git pre-commit hook")
+	fmt.Fprintf(out, "  %-12s %s\n", "lsp", "Serve wikifmt and nuglint diagnostics to editors over LSP")
+	fmt.Fprintf(out, "  %-12s %s\n", "mcp", "Serve linters as Model Context Protocol tools for agents")
+	fmt.Fprintln(out, "Global options:")
+	fmt.Fprintln(out, "  --baseline FILE  Suppress findings recorded in FILE and mark the rest new/absent")
+	fmt.Fprintln(out, "  --config FILE    Project config (default: nearest "+config.FileName+")")
+	fmt.Fprintln(out, "  --format NAME    Output format: "+strings.Join(format.Names(), ", ")+" (default: "+format.Default+")")
+	fmt.Fprintln(out, "  --fail-on LEVEL  Exit 1 when results at or above LEVEL remain: error (default), warning, note, none")
+	fmt.Fprintln(out, "  --changed-since REF  Only lint files changed since the git REF")
+	fmt.Fprintln(out, "  --staged         Only lint files staged for commit")
+	fmt.Fprintln(out, "Exit status: 0 clean, 1 findings at or above the fail-on level, 2 usage error or tool failure")
+}
+
+func runBaseline(args []string) error {
+	if len(args) == 0 || args[0] != "write" {
+		return errors.New("usage: lintkit baseline write [--output FILE] <command> [options]")
 	}
 
-	dbPaths := fs.Args()
-	if len(dbPaths) == 0 {
-		return fmt.Errorf("at least one database path is required")
+	fs := flag.NewFlagSet("baseline write", flag.ContinueOnError)
+	output := fs.String("output", baseline.DefaultPath, "Path of the baseline file to write")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
 	}
 
-	expectedFile, err := os.Open(*expectedPath)
-	if err != nil {
-		return fmt.Errorf("open expected schema: %w", err)
+	rest := fs.Args()
+	if len(rest) == 0 {
+		return errors.New("baseline write requires a command to run")
+	}
+	cmd, ok := lookupCommand(rest[0])
+	if !ok {
+		return fmt.Errorf("unknown command: %s", rest[0])
 	}
-	defer func() { _ = expectedFile.Close() }()
 
-	expected, err := dbschema.ParseExpectedSchema(expectedFile)
+	log, err := cmd.run(rest[1:])
 	if err != nil {
 		return err
 	}
-
-	log := sarif.NewLog()
-	run := sarif.Run{Tool: sarif.Tool{Driver: sarif.Driver{Name: "lintkit-dbschema"}}}
-
-	ctx := context.Background()
-	for _, dbPath := range dbPaths {
-		actual, err := dbschema.LoadActualSchema(ctx, dbPath)
-		if err != nil {
-			return err
-		}
-
-		findings := dbschema.CompareSchemas(expected, actual)
-		run.Results = append(run.Results, dbschema.ToSARIF(dbPath, findings)...)
+	if log == nil {
+		return errors.New("command produced no SARIF log")
 	}
+	postprocess(log)
 
-	log.Runs = append(log.Runs, run)
+	b := baseline.FromLog(log)
+	if err := b.Save(*output); err != nil {
+		return fmt.Errorf("write baseline: %w", err)
+	}
 
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(log)
+	fmt.Fprintf(os.Stderr, "baseline: recorded %d finding(s) in %s\n", len(b.Results), *output)
+	return nil
 }
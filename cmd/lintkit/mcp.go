@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"runtime/debug"
+
+	"github.com/dkoosis/lintkit/pkg/mcp"
+)
+
+// runMCP serves the registered linters as Model Context Protocol tools over
+// stdin and stdout. Results get the same exclusion, rule override, and
+// inline suppression handling as on the command line.
+func runMCP(args []string) error {
+	fs := flag.NewFlagSet("mcp", flag.ContinueOnError)
+	//nolint:errcheck // CLI usage output
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: lintkit mcp\nServes every linter, plus %s, as an MCP tool on stdio.\n", mcp.ListRulesTool)
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	version := "(devel)"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		version = info.Main.Version
+	}
+	server := mcp.NewServer(registry, mcp.Options{
+		Version:     version,
+		Postprocess: postprocess,
+		Root:        srcRoot,
+	})
+	return server.Serve(context.Background(), os.Stdin, os.Stdout)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dkoosis/lintkit/pkg/report"
+)
+
+// runReport implements `lintkit report`, rendering SARIF files as a
+// self-contained HTML page.
+func runReport(args []string) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	htmlPath := fs.String("html", "", "Path of the HTML report to write")
+	title := fs.String("title", "", "Report title (default: lintkit report)")
+	sourceRoot := fs.String("source-root", ".", "Directory relative result paths without a uriBaseId are resolved against for source snippets")
+	//nolint:errcheck // CLI usage output
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: lintkit report --html out.html [--title T] [--source-root DIR] input.sarif...\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *htmlPath == "" {
+		fs.Usage()
+		return errors.New("--html is required")
+	}
+	if fs.NArg() == 0 {
+		return errors.New("report requires at least one SARIF file")
+	}
+
+	log, err := readLogs(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(*htmlPath)
+	if err != nil {
+		return fmt.Errorf("create report: %w", err)
+	}
+	if err := report.WriteHTML(out, log, report.Options{Title: *title, SourceRoot: *sourceRoot}); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("write report: %w", err)
+	}
+	return out.Close()
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dkoosis/lintkit/pkg/catalog"
+)
+
+// runRules lists the rules of every linter, or of the linters named in
+// args.
+func runRules(args []string) error {
+	fs := flag.NewFlagSet("rules", flag.ContinueOnError)
+	//nolint:errcheck // CLI usage output
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: lintkit rules [LINTER...]")
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	only := map[string]bool{}
+	for _, name := range fs.Args() {
+		if _, ok := registry.New(name); !ok {
+			return fmt.Errorf("unknown linter: %s", name)
+		}
+		only[name] = true
+	}
+	var entries []catalog.Entry
+	for _, e := range catalog.Build(registry) {
+		if len(only) == 0 || only[e.Linter] {
+			entries = append(entries, e)
+		}
+	}
+	return catalog.WriteTable(os.Stdout, entries)
+}
+
+// runExplain prints the documentation of a single rule.
+func runExplain(args []string) error {
+	fs := flag.NewFlagSet("explain", flag.ContinueOnError)
+	//nolint:errcheck // CLI usage output
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: lintkit explain RULE")
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("explain takes exactly one rule ID")
+	}
+
+	e, ok := catalog.Find(catalog.Build(registry), fs.Arg(0))
+	if !ok {
+		return fmt.Errorf("unknown rule: %s (run lintkit rules to list them)", fs.Arg(0))
+	}
+	return catalog.Explain(os.Stdout, e)
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// runCommand runs several linters and merges their output. It is declared
+// in init to break the initialization cycle through lookupCommand.
+var runCommand command
+
+func init() {
+	runCommand = command{name: "run", summary: "Run several linters concurrently and merge their SARIF", run: runAll}
+}
+
+// invocation is a single linter command line inside `lintkit run`.
+type invocation struct {
+	name string
+	args []string
+}
+
+// runAll executes several subcommands concurrently and merges their runs
+// into a single log. Invocations come from the command line, separated by
+// "--", and/or from a plan file with one invocation per line.
+func runAll(args []string) (*sarif.Log, error) {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	planPath := fs.String("file", "", "Path to a plan file with one linter invocation per line")
+	//nolint:errcheck // CLI usage output
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: lintkit run [--file plan.txt] [LINTER [ARGS...] [-- LINTER [ARGS...]]...]\n")
+		fmt.Fprintf(fs.Output(), "With no linters given, every linter configured in %s runs.\n", config.FileName)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	var invocations []invocation
+	if *planPath != "" {
+		planned, err := loadPlan(*planPath)
+		if err != nil {
+			return nil, err
+		}
+		invocations = append(invocations, planned...)
+	}
+	invocations = append(invocations, splitInvocations(fs.Args())...)
+
+	// With nothing selected, run every linter configured in the project.
+	if len(invocations) == 0 {
+		for _, name := range project.Configured() {
+			invocations = append(invocations, invocation{name: name})
+		}
+	}
+
+	if len(invocations) == 0 {
+		fs.Usage()
+		return nil, errors.New("no linters selected")
+	}
+
+	for _, inv := range invocations {
+		if _, ok := registry.New(inv.name); !ok {
+			return nil, fmt.Errorf("unknown linter: %s", inv.name)
+		}
+	}
+
+	logs := make([]*sarif.Log, len(invocations))
+	errs := make([]error, len(invocations))
+	var wg sync.WaitGroup
+	for i := range invocations {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			logs[i], errs[i] = runLinter(invocations[i].name, invocations[i].args)
+		}(i)
+	}
+	wg.Wait()
+
+	merged := sarif.NewLog()
+	var failures []error
+	for i, log := range logs {
+		if log != nil {
+			merged.Runs = append(merged.Runs, log.Runs...)
+		}
+		if errs[i] != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", invocations[i].name, errs[i]))
+		}
+	}
+	return merged, errors.Join(failures...)
+}
+
+// splitInvocations splits "wikifmt docs -- nobackups ." into invocations.
+func splitInvocations(args []string) []invocation {
+	var out []invocation
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			out = append(out, invocation{name: current[0], args: current[1:]})
+		}
+		current = nil
+	}
+	for _, a := range args {
+		if a == "--" {
+			flush()
+			continue
+		}
+		current = append(current, a)
+	}
+	flush()
+	return out
+}
+
+// loadPlan reads invocations from a file. Blank lines and lines starting
+// with '#' are ignored; other lines are split on whitespace.
+func loadPlan(path string) ([]invocation, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open plan: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var out []invocation
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		out = append(out, invocation{name: fields[0], args: fields[1:]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read plan: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dkoosis/lintkit/pkg/format"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/walk"
+)
+
+const sarifUsage = `usage: lintkit sarif <operation> [options] FILE...
+Operations:
+  merge FILE...                                  Combine the runs of several logs
+  filter [--rule ID] [--level L] [--path GLOB] FILE...
+                                                 Keep matching results
+  diff OLD NEW                                   Mark results of NEW as new, unchanged, or absent
+  summary FILE...                                Count results per tool and rule
+FILE may be "-" for standard input.`
+
+// runSARIF implements `lintkit sarif`, a toolbox for SARIF produced by
+// lintkit or any other tool. Results are written in the global --format;
+// diff fails with a findingsError when new results reach the policy level.
+func runSARIF(args []string, opts globalOptions, policy lint.FailOn) error {
+	if len(args) == 0 {
+		return errors.New(sarifUsage)
+	}
+	op, args := args[0], args[1:]
+
+	switch op {
+	case "merge":
+		if len(args) == 0 {
+			return errors.New("sarif merge requires at least one FILE")
+		}
+		log, err := readLogs(args)
+		if err != nil {
+			return err
+		}
+		return writeLog(log, opts)
+
+	case "filter":
+		return runSARIFFilter(args, opts)
+
+	case "diff":
+		if len(args) != 2 {
+			return errors.New("usage: lintkit sarif diff OLD NEW")
+		}
+		older, err := readLogs(args[:1])
+		if err != nil {
+			return err
+		}
+		newer, err := readLogs(args[1:])
+		if err != nil {
+			return err
+		}
+		diff := sarif.Diff(older, newer)
+		if err := writeLog(diff, opts); err != nil {
+			return err
+		}
+		added := sarif.Filter(diff, func(_ *sarif.Run, r sarif.Result) bool { return r.BaselineState == "new" })
+		if n := policy.Count(added); n > 0 {
+			return findingsError{count: n, level: policy}
+		}
+		return nil
+
+	case "summary":
+		if len(args) == 0 {
+			return errors.New("sarif summary requires at least one FILE")
+		}
+		log, err := readLogs(args)
+		if err != nil {
+			return err
+		}
+		return writeSummary(os.Stdout, log)
+
+	default:
+		return fmt.Errorf("unknown sarif operation %q\n%s", op, sarifUsage)
+	}
+}
+
+func runSARIFFilter(args []string, opts globalOptions) error {
+	var rules, paths stringList
+	fs := flag.NewFlagSet("sarif filter", flag.ContinueOnError)
+	fs.Var(&rules, "rule", "Keep results with this rule ID (repeatable, comma-separated)")
+	fs.Var(&paths, "path", "Keep results whose artifact matches this glob (repeatable, comma-separated)")
+	level := fs.String("level", "", "Keep results at or above this level: error, warning, note")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New("sarif filter requires at least one FILE")
+	}
+	if *level != "" {
+		if _, err := lint.ParseFailOn(*level); err != nil || *level == string(lint.FailOnNone) {
+			return fmt.Errorf("invalid level %q (want error, warning, or note)", *level)
+		}
+	}
+
+	log, err := readLogs(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	filtered := sarif.Filter(log, func(_ *sarif.Run, r sarif.Result) bool {
+		if len(rules) > 0 && !contains(rules, r.RuleID) {
+			return false
+		}
+		if *level != "" && sarif.LevelRank(r.Level) < sarif.LevelRank(*level) {
+			return false
+		}
+		if len(paths) > 0 && !matchesAny(paths, r.PrimaryURI()) {
+			return false
+		}
+		return true
+	})
+	return writeLog(filtered, opts)
+}
+
+// readLogs decodes and merges the SARIF files at paths; "-" reads stdin.
+func readLogs(paths []string) (*sarif.Log, error) {
+	logs := make([]*sarif.Log, 0, len(paths))
+	for _, path := range paths {
+		log, err := readLog(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		logs = append(logs, log)
+	}
+	return sarif.Merge(logs...), nil
+}
+
+func readLog(path string) (*sarif.Log, error) {
+	if path == "-" {
+		return sarif.Decode(os.Stdin)
+	}
+	f, err := os.Open(path) //nolint:gosec // G304: path is a user-supplied input file
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return sarif.Decode(f)
+}
+
+func writeLog(log *sarif.Log, opts globalOptions) error {
+	if err := format.Write(os.Stdout, opts.format, log); err != nil {
+		return fmt.Errorf("failed to write %s output: %w", opts.format, err)
+	}
+	return nil
+}
+
+// writeSummary prints result counts per tool and per rule. Suppressed and
+// absent results are counted separately from active ones.
+func writeSummary(w io.Writer, log *sarif.Log) error {
+	type ruleKey struct{ tool, rule string }
+	type toolCounts struct{ error, warning, note, suppressed int }
+
+	var tools []string
+	byTool := map[string]*toolCounts{}
+	byRule := map[ruleKey]int{}
+	for _, run := range log.Runs {
+		tool := run.Tool.Driver.Name
+		counts := byTool[tool]
+		if counts == nil {
+			counts = &toolCounts{}
+			byTool[tool] = counts
+			tools = append(tools, tool)
+		}
+		for _, r := range run.Results {
+			if !r.IsActive() {
+				counts.suppressed++
+				continue
+			}
+			switch sarif.LevelRank(r.Level) {
+			case sarif.LevelRank("error"):
+				counts.error++
+			case sarif.LevelRank("warning"):
+				counts.warning++
+			default:
+				counts.note++
+			}
+			byRule[ruleKey{tool, r.RuleID}]++
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	//nolint:errcheck // tabwriter errors surface on Flush
+	fmt.Fprintln(tw, "TOOL\tERROR\tWARNING\tNOTE\tSUPPRESSED")
+	for _, tool := range tools {
+		c := byTool[tool]
+		//nolint:errcheck // tabwriter errors surface on Flush
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\n", tool, c.error, c.warning, c.note, c.suppressed)
+	}
+
+	if len(byRule) > 0 {
+		keys := make([]ruleKey, 0, len(byRule))
+		for k := range byRule {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if byRule[keys[i]] != byRule[keys[j]] {
+				return byRule[keys[i]] > byRule[keys[j]]
+			}
+			if keys[i].tool != keys[j].tool {
+				return keys[i].tool < keys[j].tool
+			}
+			return keys[i].rule < keys[j].rule
+		})
+		//nolint:errcheck // tabwriter errors surface on Flush
+		fmt.Fprintln(tw, "\nTOOL\tRULE\tCOUNT")
+		for _, k := range keys {
+			//nolint:errcheck // tabwriter errors surface on Flush
+			fmt.Fprintf(tw, "%s\t%s\t%d\n", k.tool, k.rule, byRule[k])
+		}
+	}
+	return tw.Flush()
+}
+
+// stringList is a repeatable flag whose values may also be comma-separated.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(v string) error {
+	for _, part := range strings.Split(v, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			*l = append(*l, part)
+		}
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAny(patterns []string, uri string) bool {
+	for _, p := range patterns {
+		if walk.MatchGlob(p, uri) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/suppress"
+)
+
+// executeStream is execute for linters that stream their results, used
+// when SARIF is written without a baseline. Each result gets the handling
+// postprocess gives a whole log and is written as soon as it is found, so
+// memory stays flat however many results there are.
+func executeStream(cmd command, args []string, policy lint.FailOn) error {
+	l, ok := registry.New(cmd.name)
+	if !ok {
+		return fmt.Errorf("unknown linter: %s", cmd.name)
+	}
+	overrides := sarif.Overrides(project.Rules)
+	suppressor := suppress.New(".")
+	reported := map[string]bool{}
+	failing := 0
+	keep := func(run *sarif.Run, r *sarif.Result) bool {
+		if project.Excluded(run.PrimaryPath(*r)) {
+			return false
+		}
+		reported[r.RuleID] = true
+		if !overrides.Result(r) {
+			return false
+		}
+		suppressor.Result(run, r)
+		if policy.Fails(*r) {
+			failing++
+		}
+		return true
+	}
+
+	w := sarif.NewWriter(os.Stdout)
+	inv, runErr := cmd.stream(args, w, keep)
+	if inv.StartTimeUTC.IsZero() {
+		return runErr // flag errors: the run never began
+	}
+
+	result, code := runErr, exitFailure
+	if runErr == nil {
+		result, code = nil, 0
+		if failing > 0 {
+			result, code = findingsError{count: failing, level: policy}, exitFindings
+		}
+	}
+	inv.CommandLine = commandLine(os.Args)
+	inv.ExitCode = &code
+	inv.RuleConfigurationOverrides = overrides.Configurations(lint.Driver(l), reported)
+
+	if err := w.EndRun(inv); err != nil {
+		return fmt.Errorf("failed to write sarif output: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to write sarif output: %w", err)
+	}
+	return result
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/format"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/watch"
+)
+
+// runWatch runs linters, then polls their inputs and re-runs the linters
+// whose inputs changed, printing the findings that appeared or
+// were resolved. It stops on interrupt.
+func runWatch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	interval := fs.Duration("interval", watch.DefaultInterval, "How often to poll for changes")
+	debounce := fs.Duration("debounce", watch.DefaultDebounce, "Quiet period after a change before linters re-run")
+	//nolint:errcheck // CLI usage output
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: lintkit watch [--interval D] [--debounce D] [LINTER [ARGS...] [-- LINTER [ARGS...]]...]\n")
+		fmt.Fprintf(fs.Output(), "With no linters given, every linter configured in %s runs.\n", config.FileName)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	invocations := splitInvocations(fs.Args())
+	if len(invocations) == 0 {
+		for _, name := range project.Configured() {
+			invocations = append(invocations, invocation{name: name})
+		}
+	}
+	if len(invocations) == 0 {
+		fs.Usage()
+		return errors.New("no linters selected")
+	}
+	// Each linter is prepared with its invocation's flags, so that it
+	// matches and lists the inputs those flags name.
+	linters := make([]lint.Linter, len(invocations))
+	var roots []string
+	for i, inv := range invocations {
+		l, req, err := prepareLinter(inv.name, inv.args)
+		if err != nil {
+			return fmt.Errorf("%s: %w", inv.name, err)
+		}
+		linters[i] = l
+		roots = append(roots, lint.Inputs(l, req)...)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	poller, err := watch.NewPoller(watchRoots(roots), project.WalkOptions())
+	if err != nil {
+		return err
+	}
+
+	previous := make([]*sarif.Log, len(invocations))
+	rerun := func(i int) {
+		inv := invocations[i]
+		log, err := runLinter(inv.name, inv.args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "watch: %s: %v\n", inv.name, err)
+			return
+		}
+		postprocess(log)
+
+		older := previous[i]
+		if older == nil {
+			older = sarif.NewLog()
+		}
+		if err := printChanges(os.Stdout, inv.name, sarif.Diff(older, log)); err != nil {
+			fmt.Fprintf(os.Stderr, "watch: %s: %v\n", inv.name, err)
+		}
+		previous[i] = log
+	}
+
+	for i := range invocations {
+		rerun(i)
+	}
+	fmt.Fprintln(os.Stderr, "watch: waiting for changes (interrupt to stop)")
+
+	err = watch.Watch(ctx, poller, *interval, *debounce, func(changed []string) error {
+		for i, l := range linters {
+			for _, path := range changed {
+				if lint.Affected(l, path) {
+					rerun(i)
+					break
+				}
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// watchRoots returns roots cleaned, without duplicates, and without those
+// inside another root, so that no file is polled twice.
+func watchRoots(roots []string) []string {
+	cleaned := make([]string, 0, len(roots))
+	for _, root := range roots {
+		cleaned = append(cleaned, filepath.Clean(root))
+	}
+	sort.Strings(cleaned) // parents sort before what they contain
+
+	var out []string
+	for _, root := range cleaned {
+		if !slices.ContainsFunc(out, func(parent string) bool { return below(parent, root) }) {
+			out = append(out, root)
+		}
+	}
+	return out
+}
+
+// below reports whether path is dir or lies below it.
+func below(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// countResults returns the number of results across all runs.
+func countResults(log *sarif.Log) int {
+	n := 0
+	for _, run := range log.Runs {
+		n += len(run.Results)
+	}
+	return n
+}
+
+// printChanges writes a header and the findings of a diff that appeared
+// ("+") and were resolved ("-"). Nothing is written when nothing changed.
+func printChanges(w io.Writer, name string, diff *sarif.Log) error {
+	appeared := sarif.Filter(diff, func(_ *sarif.Run, r sarif.Result) bool { return r.BaselineState == "new" })
+	resolved := sarif.Filter(diff, func(_ *sarif.Run, r sarif.Result) bool { return r.BaselineState == "absent" })
+	// Resolved results are no longer active; clear their state so the text
+	// formatter lists them.
+	for i := range resolved.Runs {
+		for j := range resolved.Runs[i].Results {
+			resolved.Runs[i].Results[j].BaselineState = ""
+		}
+	}
+
+	added, removed := countResults(appeared), countResults(resolved)
+	if added == 0 && removed == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "[%s] %s: %d appeared, %d resolved\n", time.Now().Format("15:04:05"), name, added, removed); err != nil {
+		return err
+	}
+	for _, part := range []struct {
+		prefix string
+		log    *sarif.Log
+	}{{"+ ", appeared}, {"- ", resolved}} {
+		var buf bytes.Buffer
+		if err := format.Write(&buf, "text", part.log); err != nil {
+			return err
+		}
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			if _, err := fmt.Fprintln(w, part.prefix+scanner.Text()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
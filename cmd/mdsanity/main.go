@@ -2,28 +2,42 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/dkoosis/lintkit/pkg/format"
 	"github.com/dkoosis/lintkit/pkg/mdsanity"
+	"github.com/dkoosis/lintkit/pkg/sarif"
 )
 
 func main() {
 	root := flag.String("root", ".", "repository root to analyze")
+	outFormat := flag.String("format", format.Default, "output format: "+strings.Join(format.Names(), ", "))
 	flag.Parse()
 
+	if _, err := format.Lookup(*outFormat); err != nil {
+		fmt.Fprintf(os.Stderr, "mdsanity: %v\n", err)
+		os.Exit(1)
+	}
+
 	log, err := mdsanity.Run(mdsanity.Config{RepoRoot: *root})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "mdsanity: %v\n", err)
 		os.Exit(1)
 	}
 
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(log); err != nil {
-		fmt.Fprintf(os.Stderr, "mdsanity: failed to encode SARIF: %v\n", err)
+	for i := range log.Runs {
+		sarif.Rebase(log.Runs[i].Results, *root)
+	}
+	if err := sarif.Relativize(log, *root); err != nil {
+		fmt.Fprintf(os.Stderr, "mdsanity: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := format.Write(os.Stdout, *outFormat, log); err != nil {
+		fmt.Fprintf(os.Stderr, "mdsanity: failed to write output: %v\n", err)
 		os.Exit(1)
 	}
 }
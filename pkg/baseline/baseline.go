@@ -0,0 +1,187 @@
+// Package baseline records accepted SARIF findings and compares later runs
+// against them.
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// FormatVersion is the on-disk baseline format version.
+const FormatVersion = 1
+
+// DefaultPath is the baseline file used when none is given.
+const DefaultPath = ".lintkit-baseline.json"
+
+// File is the on-disk baseline: one entry per accepted finding.
+type File struct {
+	Version int     `json:"version"`
+	Results []Entry `json:"results"`
+}
+
+// Entry identifies an accepted finding. Only Tool and Fingerprint are used
+// for matching; the remaining fields keep the file reviewable and allow
+// absent findings to be reported.
+type Entry struct {
+	Tool        string `json:"tool"`
+	RuleID      string `json:"ruleId"`
+	URI         string `json:"uri,omitempty"`
+	Fingerprint string `json:"fingerprint"`
+	Level       string `json:"level,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// FromLog builds a baseline from every active result in the log.
+func FromLog(log *sarif.Log) *File {
+	f := &File{Version: FormatVersion, Results: []Entry{}}
+	for _, run := range log.Runs {
+		for _, r := range run.Results {
+			if !r.IsActive() {
+				continue
+			}
+			f.Results = append(f.Results, Entry{
+				Tool:        run.Tool.Driver.Name,
+				RuleID:      r.RuleID,
+				URI:         r.PrimaryURI(),
+				Fingerprint: r.StableFingerprint(),
+				Level:       r.Level,
+				Message:     r.Message.Formatted(),
+			})
+		}
+	}
+	sort.SliceStable(f.Results, func(i, j int) bool {
+		a, b := f.Results[i], f.Results[j]
+		if a.Tool != b.Tool {
+			return a.Tool < b.Tool
+		}
+		if a.URI != b.URI {
+			return a.URI < b.URI
+		}
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+	return f
+}
+
+// Load reads a baseline file from disk.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse baseline %s: %w", path, err)
+	}
+	if f.Version != FormatVersion {
+		return nil, fmt.Errorf("baseline %s: unsupported version %d", path, f.Version)
+	}
+
+	return &f, nil
+}
+
+// Save writes the baseline to path as indented JSON.
+func (f *File) Save(path string) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := f.Encode(out); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// Encode writes the baseline as indented JSON.
+func (f *File) Encode(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(f)
+}
+
+// Apply compares the log against the baseline. Results whose fingerprint is
+// in the baseline are marked unchanged and suppressed; the rest are marked
+// new. Baseline entries for tools present in the log that no longer match
+// any result are appended as absent results.
+func (f *File) Apply(log *sarif.Log) {
+	remaining := map[string]map[string][]Entry{}
+	for _, e := range f.Results {
+		if remaining[e.Tool] == nil {
+			remaining[e.Tool] = map[string][]Entry{}
+		}
+		remaining[e.Tool][e.Fingerprint] = append(remaining[e.Tool][e.Fingerprint], e)
+	}
+
+	for i := range log.Runs {
+		run := &log.Runs[i]
+		accepted := remaining[run.Tool.Driver.Name]
+		for j := range run.Results {
+			r := &run.Results[j]
+			fp := r.StableFingerprint()
+			if entries := accepted[fp]; len(entries) > 0 {
+				accepted[fp] = entries[1:]
+				r.BaselineState = "unchanged"
+				r.Suppressions = append(r.Suppressions, sarif.Suppression{
+					Kind:          "external",
+					Status:        "accepted",
+					Justification: "accepted in baseline",
+				})
+				continue
+			}
+			r.BaselineState = "new"
+		}
+
+		base := ""
+		if _, ok := run.OriginalURIBaseIDs[sarif.SrcRoot]; ok {
+			base = sarif.SrcRoot
+		}
+		absent := absentResults(accepted, base)
+		if len(absent) > 0 {
+			run.Results = append(run.Results, absent...)
+			run.IndexRules()
+		}
+		delete(remaining, run.Tool.Driver.Name)
+	}
+}
+
+// absentResults turns unmatched entries into absent results. Relative
+// entry URIs are placed under base, the uriBaseId of the run's results.
+func absentResults(accepted map[string][]Entry, base string) []sarif.Result {
+	fps := make([]string, 0, len(accepted))
+	for fp := range accepted {
+		fps = append(fps, fp)
+	}
+	sort.Strings(fps)
+
+	var results []sarif.Result
+	for _, fp := range fps {
+		for _, e := range accepted[fp] {
+			r := sarif.Result{
+				RuleID:              e.RuleID,
+				Level:               e.Level,
+				Message:             sarif.Message{Text: e.Message},
+				PartialFingerprints: map[string]string{sarif.FingerprintKey: e.Fingerprint},
+				BaselineState:       "absent",
+			}
+			if e.URI != "" {
+				loc := sarif.ArtifactLocation{URI: e.URI}
+				if !strings.HasPrefix(e.URI, "file://") {
+					loc.URIBaseID = base
+				}
+				r.Locations = []sarif.Location{{PhysicalLocation: sarif.PhysicalLocation{ArtifactLocation: loc}}}
+			}
+			results = append(results, r)
+		}
+	}
+	return results
+}
@@ -0,0 +1,96 @@
+package baseline
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+func TestApplyMarksBaselineStates(t *testing.T) {
+	before := logWith(
+		finding("rule-a", "a.md", "one"),
+		finding("rule-b", "b.md", "two"),
+	)
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := FromLog(before).Save(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(loaded.Results) != 2 {
+		t.Fatalf("expected 2 baseline entries, got %d", len(loaded.Results))
+	}
+
+	after := logWith(
+		finding("rule-a", "a.md", "one"),
+		finding("rule-c", "c.md", "three"),
+	)
+	loaded.Apply(after)
+
+	states := map[string]sarif.Result{}
+	for _, r := range after.Runs[0].Results {
+		states[r.RuleID] = r
+	}
+
+	if got := states["rule-a"]; got.BaselineState != "unchanged" || !got.IsSuppressed() {
+		t.Fatalf("expected rule-a unchanged and suppressed, got %+v", got)
+	}
+	if got := states["rule-c"]; got.BaselineState != "new" || !got.IsActive() {
+		t.Fatalf("expected rule-c new and active, got %+v", got)
+	}
+	if got := states["rule-b"]; got.BaselineState != "absent" || got.IsActive() {
+		t.Fatalf("expected rule-b absent and inactive, got %+v", got)
+	}
+}
+
+func TestApplyMatchesDuplicateFingerprintsByCount(t *testing.T) {
+	b := FromLog(logWith(finding("rule-a", "a.md", "x")))
+
+	after := logWith(finding("rule-a", "a.md", "x"), finding("rule-a", "a.md", "x"))
+	b.Apply(after)
+
+	var unchanged, fresh int
+	for _, r := range after.Runs[0].Results {
+		switch r.BaselineState {
+		case "unchanged":
+			unchanged++
+		case "new":
+			fresh++
+		}
+	}
+	if unchanged != 1 || fresh != 1 {
+		t.Fatalf("expected one unchanged and one new, got %d/%d", unchanged, fresh)
+	}
+}
+
+func TestApplyIgnoresOtherTools(t *testing.T) {
+	b := &File{Version: FormatVersion, Results: []Entry{{Tool: "other", RuleID: "rule-z", Fingerprint: "abc"}}}
+
+	log := logWith(finding("rule-a", "a.md", "one"))
+	b.Apply(log)
+
+	if len(log.Runs[0].Results) != 1 {
+		t.Fatalf("expected no absent results for tools not in the log, got %d results", len(log.Runs[0].Results))
+	}
+}
+
+func logWith(results ...sarif.Result) *sarif.Log {
+	log := sarif.NewLog()
+	log.Runs = append(log.Runs, sarif.NewRun(sarif.Driver{Name: "lintkit-test"}, results))
+	return log
+}
+
+func finding(rule, uri, subject string) sarif.Result {
+	return sarif.Result{
+		RuleID:  rule,
+		Level:   "warning",
+		Message: sarif.Message{Text: rule + " on " + uri},
+		Locations: []sarif.Location{{
+			PhysicalLocation: sarif.PhysicalLocation{ArtifactLocation: sarif.ArtifactLocation{URI: uri}},
+		}},
+	}.WithFingerprint(subject)
+}
@@ -0,0 +1,136 @@
+// Package catalog is the single place that describes every rule lintkit
+// reports. Identity, default level, and short descriptions come from the
+// linters themselves; the catalog adds the longer documentation shown by
+// `lintkit explain`.
+package catalog
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// Doc explains a rule beyond its SARIF descriptor.
+type Doc struct {
+	// Rationale says why the rule exists.
+	Rationale string
+	// Bad is an example input that violates the rule.
+	Bad string
+	// Good is the same example after fixing it.
+	Good string
+	// Options lists the flags and config keys that change the rule.
+	Options []string
+}
+
+// Entry is one rule of a registered linter.
+type Entry struct {
+	// Linter is the command name of the linter reporting the rule.
+	Linter string
+	// Tool is the SARIF driver name of that linter.
+	Tool string
+	Rule sarif.ReportingDescriptor
+	// Doc is empty for rules of linters the catalog does not document.
+	Doc Doc
+}
+
+// Level returns the rule's default level; SARIF defaults to warning.
+func (e Entry) Level() string {
+	if e.Rule.DefaultConfiguration != nil && e.Rule.DefaultConfiguration.Level != "" {
+		return e.Rule.DefaultConfiguration.Level
+	}
+	return "warning"
+}
+
+// Short returns the rule's one-line description.
+func (e Entry) Short() string {
+	return text(e.Rule.ShortDescription)
+}
+
+func text(m *sarif.MultiformatMessage) string {
+	if m == nil {
+		return ""
+	}
+	return strings.TrimSpace(m.Text)
+}
+
+// Build lists the rules of every linter in reg, ordered by linter name and
+// then in each linter's own order.
+func Build(reg *lint.Registry) []Entry {
+	var entries []Entry
+	for _, name := range reg.Names() {
+		l, _ := reg.New(name)
+		tool := lint.ToolName(l)
+		for _, r := range l.Rules() {
+			entries = append(entries, Entry{Linter: name, Tool: tool, Rule: r, Doc: docs[r.ID]})
+		}
+	}
+	return entries
+}
+
+// Find returns the entry for rule id.
+func Find(entries []Entry, id string) (Entry, bool) {
+	for _, e := range entries {
+		if e.Rule.ID == id {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// WriteTable writes one aligned line per entry: linter, rule ID, default
+// level, and short description.
+func WriteTable(w io.Writer, entries []Entry) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LINTER\tRULE\tLEVEL\tDESCRIPTION")
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", e.Linter, e.Rule.ID, e.Level(), e.Short())
+	}
+	return tw.Flush()
+}
+
+// Explain writes the full documentation of an entry as plain text.
+func Explain(w io.Writer, e Entry) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s, default level %s)\n", e.Rule.ID, e.Linter, e.Level())
+	if short := e.Short(); short != "" {
+		fmt.Fprintf(&b, "\n%s\n", short)
+	}
+	if full := text(e.Rule.FullDescription); full != "" {
+		fmt.Fprintf(&b, "\n%s\n", full)
+	}
+	if e.Doc.Rationale != "" {
+		fmt.Fprintf(&b, "\nWhy:\n%s\n", indent(e.Doc.Rationale))
+	}
+	if e.Doc.Bad != "" {
+		fmt.Fprintf(&b, "\nViolating:\n%s\n", indent(e.Doc.Bad))
+	}
+	if e.Doc.Good != "" {
+		fmt.Fprintf(&b, "\nFixed:\n%s\n", indent(e.Doc.Good))
+	}
+	if help := text(e.Rule.Help); help != "" {
+		fmt.Fprintf(&b, "\nHow to fix:\n%s\n", indent(help))
+	}
+	if len(e.Doc.Options) > 0 {
+		b.WriteString("\nConfiguration:\n")
+		for _, o := range e.Doc.Options {
+			fmt.Fprintf(&b, "  - %s\n", o)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// indent prefixes every line of s with two spaces.
+func indent(s string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "  " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
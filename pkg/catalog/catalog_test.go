@@ -0,0 +1,46 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dkoosis/lintkit/pkg/lint/builtin"
+)
+
+func TestEveryBuiltinRuleIsDocumented(t *testing.T) {
+	entries := Build(builtin.NewRegistry(nil))
+	seen := map[string]bool{}
+	for _, e := range entries {
+		if seen[e.Rule.ID] {
+			t.Fatalf("rule %s is reported by more than one linter", e.Rule.ID)
+		}
+		seen[e.Rule.ID] = true
+		if e.Doc.Rationale == "" || e.Doc.Bad == "" || e.Doc.Good == "" {
+			t.Fatalf("rule %s (%s) lacks rationale or examples", e.Rule.ID, e.Linter)
+		}
+		if e.Short() == "" {
+			t.Fatalf("rule %s has no short description", e.Rule.ID)
+		}
+	}
+	for id := range docs {
+		if !seen[id] {
+			t.Fatalf("docs describe unknown rule %s", id)
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	e, ok := Find(Build(builtin.NewRegistry(nil)), "wiki-link-broken")
+	if !ok {
+		t.Fatalf("wiki-link-broken not found")
+	}
+	var b strings.Builder
+	if err := Explain(&b, e); err != nil {
+		t.Fatalf("Explain: %v", err)
+	}
+	for _, want := range []string{"wiki-link-broken (wikifmt, default level error)", "Why:\n", "Violating:\n  See [[deploymnet]]", "Fixed:\n", "Configuration:\n  - wikifmt.paths"} {
+		if !strings.Contains(b.String(), want) {
+			t.Fatalf("explanation missing %q:\n%s", want, b.String())
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package catalog
+
+// docs documents the rules of the bundled linters, keyed by rule ID.
+var docs = map[string]Doc{
+	// docsprawl
+	"doc-readme-too-large": {
+		Rationale: "A README is the first thing readers open. Past a few hundred lines nobody reads it end to end, and sections nobody reads stop being kept up to date.",
+		Bad:       "README.md with 900 lines: install guide, API reference, changelog, FAQ",
+		Good:      "README.md with 120 lines linking docs/install.md, docs/api.md, and CHANGELOG.md",
+		Options:   []string{"--max-readme N / docsprawl.max_readme_lines (default 500)"},
+	},
+	"doc-too-many-files": {
+		Rationale: "A flat directory of many documents has no structure to navigate by, and similar files pile up next to each other.",
+		Bad:       "docs/ holding 25 markdown files side by side",
+		Good:      "docs/guides/, docs/reference/, and docs/adr/ holding the same files by topic",
+		Options:   []string{"--max-files N / docsprawl.max_files_per_dir (default 10)"},
+	},
+	"doc-orphan": {
+		Rationale: "A document that no README links to, directly or through other documents, is only found by accident and usually goes stale.",
+		Bad:       "docs/deploy.md, linked from nowhere",
+		Good:      "README.md:\n  See [deployment](docs/deploy.md).",
+		Options:   []string{"docsprawl.paths: roots whose README.md files are the starting points"},
+	},
+	"doc-duplicate": {
+		Rationale: "Copies of a document drift apart as only one of them gets edited, leaving readers to guess which is right.",
+		Bad:       "docs/setup.md and guides/setup-copy.md with the same text",
+		Good:      "docs/setup.md only; guides/ links to it",
+		Options:   []string{"--duplicate-cutoff F / docsprawl.duplicate_cutoff (Jaccard similarity in (0, 1], default 0.9)"},
+	},
+
+	// dbsanity
+	"db-row-drift": {
+		Rationale: "A table that suddenly loses or gains many rows usually means a broken import, a bad migration, or a runaway job.",
+		Bad:       "baseline.json:\n  {\"tables\": {\"users\": 1000}}\nusers now has 700 rows (30% drop)",
+		Good:      "users has 980 rows, or the baseline is updated after an intended cleanup:\n  {\"tables\": {\"users\": 700}}",
+		Options: []string{
+			"--baseline FILE / dbsanity.baseline: expected row counts",
+			"--threshold PCT / dbsanity.threshold: allowed drift in percent (default 20)",
+		},
+	},
+	"db-check-info": {
+		Rationale: "Reporting each check's current value keeps a record of the data next to the findings, so reviewers see the numbers a drift is measured against.",
+		Bad:       "(informational; nothing to fix)",
+		Good:      "checks:\n  - name: active_users\n    type: scalar\n    query: SELECT COUNT(*) FROM users WHERE active = 1",
+		Options:   []string{"--config FILE / dbsanity.checks: the data checks to run"},
+	},
+	"db-check-drift": {
+		Rationale: "Week-over-week changes in a check's value surface slow data problems that no single snapshot shows.",
+		Bad:       "active_users was 1200 last week and is 450 now",
+		Good:      "the change is explained, or the check is updated with --update to record the new value",
+		Options: []string{
+			"--config FILE / dbsanity.checks: the data checks to run",
+			"--history FILE / dbsanity.history: snapshots compared against",
+			"--update: record the current values in the history file",
+		},
+	},
+
+	// dbschema
+	"db-schema-missing-table": {
+		Rationale: "Code written against the expected schema fails at runtime when a table it queries does not exist.",
+		Bad:       "expected.sql:\n  CREATE TABLE audit_log (id INTEGER PRIMARY KEY);\ndatabase has no audit_log table",
+		Good:      "the migration creating audit_log has been applied",
+		Options:   []string{"--expected FILE / dbschema.expected: the expected DDL"},
+	},
+	"db-schema-missing-column": {
+		Rationale: "Queries naming a column the database lacks fail at runtime.",
+		Bad:       "expected.sql:\n  CREATE TABLE users (id INTEGER, email TEXT);\ndatabase users table has only id",
+		Good:      "ALTER TABLE users ADD COLUMN email TEXT;",
+		Options:   []string{"--expected FILE / dbschema.expected: the expected DDL"},
+	},
+	"db-schema-type-mismatch": {
+		Rationale: "SQLite stores whatever it is given, so a column declared with the wrong type silently changes comparisons and sort order.",
+		Bad:       "expected: created_at INTEGER\ndatabase: created_at TEXT",
+		Good:      "both declare created_at INTEGER",
+		Options:   []string{"--expected FILE / dbschema.expected: the expected DDL"},
+	},
+	"db-schema-extra-column": {
+		Rationale: "A column only the database has is either a migration missing from the expected schema or a leftover that should be dropped.",
+		Bad:       "database users table has legacy_flag; expected.sql does not declare it",
+		Good:      "legacy_flag is added to expected.sql or dropped from the database",
+		Options:   []string{"--expected FILE / dbschema.expected: the expected DDL"},
+	},
+	"db-schema-extra-table": {
+		Rationale: "A table only the database has is either undocumented schema or a leftover that should be dropped.",
+		Bad:       "database has tmp_import; expected.sql does not declare it",
+		Good:      "DROP TABLE tmp_import;",
+		Options:   []string{"--expected FILE / dbschema.expected: the expected DDL"},
+	},
+
+	// filesize
+	"filesize-budget": {
+		Rationale: "Very large files are slow to review, diff, and load, and usually mix concerns that belong apart.",
+		Bad:       "rules:\n  - pattern: \"*.go\"\n    max: 500\nserver.go has 1400 lines",
+		Good:      "server.go split into handlers.go, routes.go, and middleware.go",
+		Options: []string{
+			"--rules FILE / filesize.rules: pattern and max pairs; the first matching rule applies",
+			"max is lines when a bare integer and bytes with a unit suffix (KB, MB, GB)",
+		},
+	},
+	"filesize-metrics": {
+		Rationale: "Sizes of files no rule covers show where a budget would help.",
+		Bad:       "(informational; nothing to fix)",
+		Good:      "rules:\n  - pattern: \"*.json\"\n    max: 100KB",
+		Options:   []string{"--rules FILE / filesize.rules: files matching a rule are not reported"},
+	},
+
+	// jsonl
+	"jsonl-schema": {
+		Rationale: "JSONL files are read one line at a time by other tools; a single malformed or off-schema line breaks or silently skews them.",
+		Bad:       "{\"id\": 1, \"name\": 42}",
+		Good:      "{\"id\": 1, \"name\": \"widget\"}",
+		Options: []string{
+			"--schema FILE / jsonl.schema: the JSON Schema each line must satisfy",
+			"jsonl.paths: the files or directories to check",
+		},
+	},
+
+	// mdsanity
+	"md-orphan": {
+		Rationale: "Markdown that no entry point links to is invisible to readers browsing the repository.",
+		Bad:       "docs/old-plan.md, linked from nowhere",
+		Good:      "README.md:\n  - [Plan](docs/old-plan.md)",
+		Options: []string{
+			"--root DIR / mdsanity.root: the repository root",
+			"mdsanity.entry_points: files reachability starts from (default README.md)",
+		},
+	},
+	"md-root-clutter": {
+		Rationale: "The repository root is where people look first; loose documents there crowd out the few that belong.",
+		Bad:       "DESIGN.md at the repository root",
+		Good:      "docs/design.md, linked from README.md",
+		Options:   []string{"--root DIR / mdsanity.root: the repository root"},
+	},
+	"md-ephemeral-placement": {
+		Rationale: "Drafts and scratch notes mixed in with maintained documents get mistaken for them.",
+		Bad:       "docs/api-draft.md",
+		Good:      "drafts/api-draft.md, or docs/api.md once it is final",
+		Options:   []string{"--root DIR / mdsanity.root: the repository root"},
+	},
+
+	// nobackups
+	"nobackups": {
+		Rationale: "Editor and merge leftovers add noise to the tree and can leak old content.",
+		Bad:       "config.yml.orig\nmain.go~",
+		Good:      "both files deleted and *.orig and *~ listed in .gitignore",
+		Options:   []string{"nobackups.paths: the trees to scan", "`lintkit fix` deletes the files"},
+	},
+
+	// nuglint
+	"nug-json-parse": {
+		Rationale: "A line that is not JSON stops every consumer of the knowledge graph at that line.",
+		Bad:       "{id: \"n:trap:leak\", k: \"trap\"}",
+		Good:      "{\"id\": \"n:trap:leak\", \"k\": \"trap\"}",
+		Options:   []string{"nuglint.paths: the JSONL files or directories to check"},
+	},
+	"nug-required-fields": {
+		Rationale: "The id, kind, and rationale are what make a nugget addressable and useful; without them it cannot be linked or interpreted.",
+		Bad:       "{\"id\": \"n:trap:leak\", \"k\": \"trap\"}",
+		Good:      "{\"id\": \"n:trap:leak\", \"k\": \"trap\", \"r\": \"problem: ...\\nsymptoms: ...\\nfix: ...\"}",
+		Options:   []string{"nuglint.paths: the JSONL files or directories to check"},
+	},
+	"nug-id-format": {
+		Rationale: "Predictable ids let nuggets reference each other and keep the kind visible wherever the id appears.",
+		Bad:       "{\"id\": \"n:choice:Leaky Bucket\", \"k\": \"trap\", ...}",
+		Good:      "{\"id\": \"n:trap:leaky-bucket\", \"k\": \"trap\", ...}",
+		Options:   []string{"`lintkit fix` rewrites the id to n:{kind}:{slug}"},
+	},
+	"nug-rationale-yaml": {
+		Rationale: "Tools extract individual rationale fields, which only works when r is a YAML mapping.",
+		Bad:       "\"r\": \"we picked sqlite because it is simple\"",
+		Good:      "\"r\": \"decision: sqlite\\ncontext: single host\\noptions: sqlite, postgres\\nrationale: simple to operate\"",
+	},
+	"nug-kind-structure": {
+		Rationale: "Each kind has a shape readers rely on: a trap says how to recognise and avoid it, a choice records the options considered, and so on.",
+		Bad:       "k: trap, r: \"problem: leak\"",
+		Good:      "k: trap, r: \"problem: leak\\nsymptoms: water on floor\\nworkaround: use seal\"",
+		Options: []string{
+			"trap: problem, symptoms, workaround or fix",
+			"choice: decision, context, options, rationale",
+			"map: pattern, relationships, flow or components",
+			"cite: title, author, key_points, and one of url, doi, arxiv, isbn",
+			"spark: idea, hypothesis, validation_approach",
+			"check: invariant, violation_consequence, enforcement",
+			"rule: rule_statement, do_examples, dont_examples",
+		},
+	},
+	"nug-severity-required": {
+		Rationale: "Traps are triaged by severity; without one a critical trap looks the same as a minor one.",
+		Bad:       "{\"id\": \"n:trap:leak\", \"k\": \"trap\", \"r\": \"...\"}",
+		Good:      "{\"id\": \"n:trap:leak\", \"k\": \"trap\", \"r\": \"...\", \"sev\": 2}",
+	},
+	"nug-orphan": {
+		Rationale: "Tags connect nuggets to the rest of the knowledge graph; an untagged nugget is only found by its id.",
+		Bad:       "{\"id\": \"n:trap:leak\", ..., \"tags\": []}",
+		Good:      "{\"id\": \"n:trap:leak\", ..., \"tags\": [\"plumbing\"]}",
+	},
+
+	// stale
+	"stale-artifact": {
+		Rationale: "Generated files committed next to their sources fall behind when someone edits the source and forgets to regenerate.",
+		Bad:       "rules:\n  - derived: \"docs/api.html\"\n    source: \"api/*.proto\"\napi/user.proto was edited after docs/api.html was built",
+		Good:      "docs/api.html regenerated after the last source edit",
+		Options: []string{
+			"--rules FILE / stale.rules: derived and source glob pairs",
+			"stale.paths: the roots the patterns are evaluated against",
+		},
+	},
+
+	// wikifmt
+	"wiki-frontmatter-yaml": {
+		Rationale: "Frontmatter is how wiki tooling finds a page's title, date, and tags; a page without parseable frontmatter drops out of every index.",
+		Bad:       "# Deploying\nbody",
+		Good:      "---\ntitle: Deploying\ndate: 2024-01-31\ntags:\n  - ops\n---\n# Deploying\nbody",
+		Options:   []string{"wikifmt.paths: the wiki roots", "`lintkit fix` adds a frontmatter block"},
+	},
+	"wiki-frontmatter-required": {
+		Rationale: "Indexes sort by date, group by tag, and list by title; a page missing one of them is misfiled.",
+		Bad:       "---\ntitle: Deploying\n---",
+		Good:      "---\ntitle: Deploying\ndate: 2024-01-31\ntags:\n  - ops\n---",
+		Options:   []string{"`lintkit fix` adds the missing keys, with today's date and an untagged placeholder tag"},
+	},
+	"wiki-date-format": {
+		Rationale: "One unambiguous date format sorts correctly as text and does not depend on the reader's locale.",
+		Bad:       "date: 31/01/2024",
+		Good:      "date: 2024-01-31",
+	},
+	"wiki-link-broken": {
+		Rationale: "A link to a page that does not exist sends readers nowhere and usually means a page was renamed or never written.",
+		Bad:       "See [[deploymnet]] and [setup](setup-guide.md).",
+		Good:      "See [[deployment]] and [setup](setup.md).",
+		Options:   []string{"wikifmt.paths: the roots link targets are resolved against"},
+	},
+	"wiki-tag-case-variant": {
+		Rationale: "Tag indexes are case-sensitive, so API and api split one topic into two lists.",
+		Bad:       "page one:\n  tags:\n    - API\npage two:\n  tags:\n    - api",
+		Good:      "both pages:\n  tags:\n    - api",
+		Options:   []string{"`lintkit fix` respells every variant as the most used spelling"},
+	},
+	"wiki-tag-orphan": {
+		Rationale: "A tag on a single page groups nothing and is often a typo of an existing tag.",
+		Bad:       "tags:\n  - deploymnet",
+		Good:      "tags:\n  - deployment",
+	},
+}
@@ -0,0 +1,320 @@
+// Package config loads the project-wide .lintkit.yml configuration file.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dkoosis/lintkit/pkg/dbsanity"
+	"github.com/dkoosis/lintkit/pkg/docsprawl"
+	"github.com/dkoosis/lintkit/pkg/filesize"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/mdsanity"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/stale"
+	"github.com/dkoosis/lintkit/pkg/walk"
+	"github.com/dkoosis/lintkit/pkg/yamlconf"
+)
+
+// FileName is the project configuration file discovered by Discover.
+const FileName = ".lintkit.yml"
+
+// Config is the root of .lintkit.yml. Each linter section is optional; a
+// nil section means the linter is not configured.
+type Config struct {
+	// Include lists the paths scanned by linters whose section sets none.
+	Include []string `yaml:"include"`
+	// Exclude lists glob patterns; results whose artifact matches any of
+	// them are dropped. "**" matches any number of path segments.
+	Exclude []string `yaml:"exclude"`
+	// FailOn is the lowest result level that makes lintkit exit non-zero:
+	// error, warning, note, or none. The --fail-on flag overrides it.
+	FailOn string `yaml:"fail_on"`
+	// Symlinks is the symbolic link policy for directory walks: files
+	// (default), skip, or follow.
+	Symlinks string `yaml:"symlinks"`
+	// Rules maps rule IDs to the level their results are reported at:
+	// error, warning, note, or off to drop them.
+	Rules map[string]string `yaml:"rules"`
+
+	Docsprawl *Docsprawl `yaml:"docsprawl"`
+	Wikifmt   *Paths     `yaml:"wikifmt"`
+	Stale     *Stale     `yaml:"stale"`
+	Nuglint   *Paths     `yaml:"nuglint"`
+	Filesize  *Filesize  `yaml:"filesize"`
+	Nobackups *Paths     `yaml:"nobackups"`
+	JSONL     *JSONL     `yaml:"jsonl"`
+	Dbschema  *Dbschema  `yaml:"dbschema"`
+	Dbsanity  *Dbsanity  `yaml:"dbsanity"`
+	Mdsanity  *Mdsanity  `yaml:"mdsanity"`
+
+	// dir is the directory containing the loaded file; relative paths in
+	// the file are resolved against it.
+	dir string
+}
+
+// Paths configures a linter that only needs input paths.
+type Paths struct {
+	Paths []string `yaml:"paths"`
+}
+
+// Docsprawl mirrors docsprawl.Config plus its input roots.
+type Docsprawl struct {
+	Paths            []string `yaml:"paths"`
+	docsprawl.Config `yaml:",inline"`
+}
+
+// Stale mirrors stale.Config plus the roots it is evaluated against.
+type Stale struct {
+	Paths        []string `yaml:"paths"`
+	stale.Config `yaml:",inline"`
+}
+
+// Filesize holds filesize budget rules in the same shape as the rules file.
+type Filesize struct {
+	Paths []string       `yaml:"paths"`
+	Rules []FilesizeRule `yaml:"rules"`
+}
+
+// FilesizeRule mirrors an entry of the filesize rules file.
+type FilesizeRule struct {
+	Pattern string      `yaml:"pattern"`
+	Max     interface{} `yaml:"max"`
+}
+
+// JSONL configures schema validation of JSONL files.
+type JSONL struct {
+	Schema string   `yaml:"schema"`
+	Paths  []string `yaml:"paths"`
+}
+
+// Dbschema configures schema drift detection.
+type Dbschema struct {
+	Expected  string   `yaml:"expected"`
+	Databases []string `yaml:"databases"`
+}
+
+// Dbsanity configures either legacy baseline mode (Baseline, Threshold) or
+// check mode (inline Checks, History).
+type Dbsanity struct {
+	Databases       []string `yaml:"databases"`
+	Baseline        string   `yaml:"baseline"`
+	Threshold       float64  `yaml:"threshold"`
+	History         string   `yaml:"history"`
+	dbsanity.Config `yaml:",inline"`
+}
+
+// Mdsanity mirrors mdsanity.Config.
+type Mdsanity struct {
+	mdsanity.Config `yaml:",inline"`
+}
+
+// Discover walks up from dir looking for FileName and returns its path, or
+// "" when no configuration file exists.
+func Discover(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		candidate := filepath.Join(dir, FileName)
+		info, err := os.Stat(candidate)
+		if err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Load reads and decodes a configuration file. Unknown keys, duplicate
+// keys, and values of the wrong type are errors, reported at their line
+// and column in path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := yamlconf.Decode(path, data, &cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg.dir = filepath.Dir(abs)
+	return &cfg, nil
+}
+
+// Parse decodes configuration from r as Load does. Relative paths are
+// resolved against the working directory.
+func Parse(r io.Reader) (*Config, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := yamlconf.Decode("", data, &cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// validate checks the values the YAML types alone do not constrain.
+func (c *Config) validate() error {
+	if _, err := lint.ParseFailOn(c.FailOn); err != nil {
+		return err
+	}
+	if _, err := walk.ParseSymlinks(c.Symlinks); err != nil {
+		return err
+	}
+	for id, level := range c.Rules {
+		switch level {
+		case sarif.LevelOff, "error", "warning", "note":
+		default:
+			return fmt.Errorf("rules: %s: invalid level %q (want off, error, warning, or note)", id, level)
+		}
+	}
+	if c.Filesize != nil {
+		for i, r := range c.Filesize.Rules {
+			if _, err := filesize.ParseRule(r.Pattern, r.Max); err != nil {
+				return fmt.Errorf("filesize: rule %d: %w", i, err)
+			}
+		}
+	}
+	if c.Stale != nil {
+		if err := c.Stale.Config.Validate(); err != nil {
+			return fmt.Errorf("stale: %w", err)
+		}
+	}
+	if c.Dbsanity != nil {
+		if err := c.Dbsanity.Config.Validate(); err != nil {
+			return fmt.Errorf("dbsanity: %w", err)
+		}
+	}
+	return nil
+}
+
+// Dir returns the directory relative paths are resolved against.
+func (c *Config) Dir() string {
+	if c.dir == "" {
+		return "."
+	}
+	return c.dir
+}
+
+// Resolve returns path relative to the working directory when possible,
+// interpreting relative inputs against the configuration file's directory.
+func (c *Config) Resolve(path string) string {
+	if path == "" || filepath.IsAbs(path) || c.dir == "" {
+		return path
+	}
+	abs := filepath.Join(c.dir, path)
+	wd, err := os.Getwd()
+	if err != nil {
+		return abs
+	}
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil {
+		return abs
+	}
+	return rel
+}
+
+// ResolveAll applies Resolve to each path.
+func (c *Config) ResolveAll(paths []string) []string {
+	out := make([]string, 0, len(paths))
+	for _, p := range paths {
+		out = append(out, c.Resolve(p))
+	}
+	return out
+}
+
+// PathsOr returns the resolved section paths, falling back to the global
+// include list when the section sets none.
+func (c *Config) PathsOr(section []string) []string {
+	if len(section) > 0 {
+		return c.ResolveAll(section)
+	}
+	return c.ResolveAll(c.Include)
+}
+
+// Configured returns the names of the linters that have a section, in the
+// order lintkit lists them.
+func (c *Config) Configured() []string {
+	var names []string
+	add := func(name string, set bool) {
+		if set {
+			names = append(names, name)
+		}
+	}
+	add("docsprawl", c.Docsprawl != nil)
+	add("dbsanity", c.Dbsanity != nil)
+	add("wikifmt", c.Wikifmt != nil)
+	add("stale", c.Stale != nil)
+	add("nuglint", c.Nuglint != nil)
+	add("filesize", c.Filesize != nil)
+	add("nobackups", c.Nobackups != nil)
+	add("jsonl", c.JSONL != nil)
+	add("dbschema", c.Dbschema != nil)
+	add("mdsanity", c.Mdsanity != nil)
+	return names
+}
+
+// Excluded reports whether a result URI matches a global exclude pattern.
+func (c *Config) Excluded(uri string) bool {
+	if len(c.Exclude) == 0 || uri == "" {
+		return false
+	}
+	rel := filepath.ToSlash(c.relToDir(uri))
+	for _, pattern := range c.Exclude {
+		if walk.MatchGlob(strings.TrimSpace(pattern), rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// WalkOptions returns the directory walk options implied by the config:
+// global excludes relative to the config directory and the symlink policy.
+func (c *Config) WalkOptions() walk.Options {
+	symlinks, _ := walk.ParseSymlinks(c.Symlinks) // validated by Parse
+	return walk.Options{Exclude: c.Exclude, ExcludeBase: c.Dir(), Symlinks: symlinks}
+}
+
+// relToDir expresses a working-directory-relative or absolute path relative
+// to the configuration directory.
+func (c *Config) relToDir(path string) string {
+	path = strings.TrimPrefix(path, "file://")
+	if !filepath.IsAbs(path) {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return filepath.Clean(path)
+		}
+		path = abs
+	}
+	rel, err := filepath.Rel(c.Dir(), path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filepath.Clean(path)
+	}
+	return rel
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiscoverWalksUp(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	writeFile(t, filepath.Join(root, FileName), "wikifmt:\n  paths: [wiki]\n")
+
+	found, err := Discover(nested)
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	if found != filepath.Join(root, FileName) {
+		t.Fatalf("expected %s, got %s", filepath.Join(root, FileName), found)
+	}
+}
+
+func TestLoadDecodesSections(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	writeFile(t, path, `
+include: [src]
+exclude: ["**/testdata/**"]
+docsprawl:
+  max_readme_lines: 200
+stale:
+  rules:
+    - derived: go.sum
+      source: go.mod
+filesize:
+  rules:
+    - pattern: "*.go"
+      max: 500
+    - pattern: "*.json"
+      max: 100KB
+dbsanity:
+  checks:
+    - name: nugs
+      query: SELECT COUNT(*) FROM nugs
+mdsanity:
+  entry_points: [docs/index.md]
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if cfg.Docsprawl == nil || cfg.Docsprawl.MaxReadmeLines != 200 {
+		t.Fatalf("expected docsprawl max_readme_lines 200, got %+v", cfg.Docsprawl)
+	}
+	if cfg.Stale == nil || len(cfg.Stale.Rules) != 1 || cfg.Stale.Rules[0].Source != "go.mod" {
+		t.Fatalf("unexpected stale section: %+v", cfg.Stale)
+	}
+	if cfg.Filesize == nil || len(cfg.Filesize.Rules) != 2 {
+		t.Fatalf("unexpected filesize section: %+v", cfg.Filesize)
+	}
+	if cfg.Dbsanity == nil || len(cfg.Dbsanity.Checks) != 1 {
+		t.Fatalf("unexpected dbsanity section: %+v", cfg.Dbsanity)
+	}
+	if cfg.Mdsanity == nil || len(cfg.Mdsanity.EntryPoints) != 1 {
+		t.Fatalf("unexpected mdsanity section: %+v", cfg.Mdsanity)
+	}
+
+	got := strings.Join(cfg.Configured(), ",")
+	if got != "docsprawl,dbsanity,stale,filesize,mdsanity" {
+		t.Fatalf("unexpected configured linters: %s", got)
+	}
+}
+
+func TestLoadRejectsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	writeFile(t, path, "wikifmt:\n  pathz: [wiki]\n")
+
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), path+`:2:3: unknown key "pathz"`) {
+		t.Fatalf("expected unknown key error, got %v", err)
+	}
+}
+
+func TestParseValidatesSections(t *testing.T) {
+	if _, err := Parse(strings.NewReader("include: [a]\ninclude: [b]\n")); err == nil || !strings.Contains(err.Error(), "line 2, column 1: duplicate key") {
+		t.Fatalf("expected duplicate key error, got %v", err)
+	}
+	if _, err := Parse(strings.NewReader("stale:\n  rules:\n    - derived: out.txt\n")); err == nil || !strings.Contains(err.Error(), "stale: rule 0: source is required") {
+		t.Fatalf("expected stale rule error, got %v", err)
+	}
+	if _, err := Parse(strings.NewReader("dbsanity:\n  checks:\n    - name: rows\n      query: SELECT 1\n      type: pie\n")); err == nil || !strings.Contains(err.Error(), `dbsanity: check "rows": unknown type "pie"`) {
+		t.Fatalf("expected dbsanity check error, got %v", err)
+	}
+	if _, err := Parse(strings.NewReader("filesize:\n  rules:\n    - pattern: '*.go'\n      max: 12QB\n")); err == nil || !strings.Contains(err.Error(), `filesize: rule 0: invalid max value "12QB"`) {
+		t.Fatalf("expected filesize rule error, got %v", err)
+	}
+}
+
+func TestParseRejectsInvalidFailOn(t *testing.T) {
+	if _, err := Parse(strings.NewReader("fail_on: warning\n")); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if _, err := Parse(strings.NewReader("fail_on: fatal\n")); err == nil {
+		t.Fatalf("expected invalid fail_on to be rejected")
+	}
+}
+
+func TestParseRuleLevels(t *testing.T) {
+	cfg, err := Parse(strings.NewReader("rules:\n  wiki-tag-orphan: off\n  md-root-clutter: error\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if cfg.Rules["wiki-tag-orphan"] != "off" || cfg.Rules["md-root-clutter"] != "error" {
+		t.Fatalf("unexpected rules: %v", cfg.Rules)
+	}
+	if _, err := Parse(strings.NewReader("rules:\n  doc-orphan: loud\n")); err == nil {
+		t.Fatalf("expected invalid rule level to be rejected")
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
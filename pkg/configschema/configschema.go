@@ -0,0 +1,257 @@
+// Package configschema publishes JSON Schemas for lintkit's configuration
+// files and validates files against them. Editors can use the schemas for
+// completion and hover documentation; CI can run the same checks with
+// `lintkit config validate`.
+//
+// YAML files are validated as the JSON documents they are equivalent to,
+// so one schema serves both, and mismatches are reported at their line and
+// column.
+package configschema
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/dbsanity"
+	"github.com/dkoosis/lintkit/pkg/filesize"
+	"github.com/dkoosis/lintkit/pkg/jsonl"
+	"github.com/dkoosis/lintkit/pkg/stale"
+	"github.com/dkoosis/lintkit/pkg/yamlconf"
+)
+
+//go:embed schemas/*.schema.json
+var schemas embed.FS
+
+// Kind is a kind of configuration file with a published schema.
+type Kind struct {
+	// Name identifies the kind on the command line, as in
+	// `lintkit config schema stale`.
+	Name string
+	// Description says which file the kind describes.
+	Description string
+
+	// load decodes the file as lintkit does, catching what the schema
+	// cannot express.
+	load func(path string) error
+}
+
+var kinds = []Kind{
+	{
+		Name:        "project",
+		Description: "Project configuration, " + config.FileName,
+		load: func(path string) error {
+			_, err := config.Load(path)
+			return err
+		},
+	},
+	{
+		Name:        "stale",
+		Description: "Rules file of lintkit stale --rules",
+		load: func(path string) error {
+			_, err := stale.LoadConfig(path)
+			return err
+		},
+	},
+	{
+		Name:        "filesize",
+		Description: "Rules file of lintkit filesize --rules",
+		load: func(path string) error {
+			_, err := filesize.LoadRules(path)
+			return err
+		},
+	},
+	{
+		Name:        "dbsanity",
+		Description: "Checks file of lintkit dbsanity --config",
+		load: func(path string) error {
+			_, err := dbsanity.LoadConfig(path)
+			return err
+		},
+	},
+	{
+		Name:        "dbsanity-baseline",
+		Description: "Row count baseline of lintkit dbsanity --baseline",
+		load: func(path string) error {
+			if _, err := dbsanity.LoadBaseline(path); err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			return nil
+		},
+	},
+	{
+		Name:        "dbsanity-history",
+		Description: "History file written by lintkit dbsanity --history",
+		load: func(path string) error {
+			if _, err := dbsanity.LoadHistory(path); err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			return nil
+		},
+	},
+}
+
+// Kinds returns every kind of configuration file, project first.
+func Kinds() []Kind {
+	return append([]Kind(nil), kinds...)
+}
+
+// Lookup returns the kind with the given name.
+func Lookup(name string) (Kind, bool) {
+	for _, k := range kinds {
+		if k.Name == name {
+			return k, true
+		}
+	}
+	return Kind{}, false
+}
+
+// Schema returns the kind's JSON Schema document.
+func (k Kind) Schema() []byte {
+	data, err := schemas.ReadFile("schemas/" + k.Name + ".schema.json")
+	if err != nil {
+		panic(fmt.Sprintf("configschema: no schema for %s: %v", k.Name, err))
+	}
+	return data
+}
+
+// Check validates the file at path against the kind's schema and then
+// loads it as lintkit would.
+func (k Kind) Check(path string) error {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is a user-supplied config file
+	if err != nil {
+		return err
+	}
+	if err := k.Validate(path, data); err != nil {
+		return err
+	}
+	return k.load(path)
+}
+
+// Validate checks the YAML or JSON document in data against the kind's
+// schema. file names the document in errors, which are *yamlconf.Error
+// values positioned at the offending key or value.
+func (k Kind) Validate(file string, data []byte) error {
+	validator, err := jsonl.ParseValidator(k.Schema())
+	if err != nil {
+		return err
+	}
+	root, err := yamlconf.Parse(file, 1, data)
+	if err != nil {
+		return err
+	}
+
+	var value interface{} = map[string]interface{}{} // an empty file is an empty object
+	if root != nil {
+		value = jsonValue(root)
+	}
+	err = validator.Validate(value)
+	var verr *jsonl.ValidationError
+	if !errors.As(err, &verr) {
+		return err
+	}
+	line, column := 1, 0
+	if at := locate(root, verr); at != nil {
+		line, column = at.Line, at.Column
+	}
+	return &yamlconf.Error{File: file, Line: line, Column: column, Msg: verr.Error()}
+}
+
+// jsonValue converts n to the value encoding/json would decode from the
+// equivalent JSON document.
+func jsonValue(n *yaml.Node) interface{} {
+	switch n.Kind {
+	case yaml.AliasNode:
+		return jsonValue(n.Alias)
+	case yaml.MappingNode:
+		obj := make(map[string]interface{}, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			obj[n.Content[i].Value] = jsonValue(n.Content[i+1])
+		}
+		return obj
+	case yaml.SequenceNode:
+		arr := make([]interface{}, len(n.Content))
+		for i, item := range n.Content {
+			arr[i] = jsonValue(item)
+		}
+		return arr
+	}
+
+	switch n.ShortTag() {
+	case "!!null":
+		return nil
+	case "!!bool":
+		var b bool
+		if n.Decode(&b) == nil {
+			return b
+		}
+	case "!!int", "!!float":
+		var f float64
+		if n.Decode(&f) == nil {
+			return f
+		}
+	}
+	return n.Value
+}
+
+// locate returns the node a validation error is about: the offending key
+// when there is one, else the value at the error's path.
+func locate(n *yaml.Node, err *jsonl.ValidationError) *yaml.Node {
+	if n == nil {
+		return nil
+	}
+	for _, p := range err.Path {
+		next := child(n, p)
+		if next == nil {
+			break
+		}
+		n = next
+	}
+	if err.Key != "" {
+		if key := mappingKey(n, err.Key); key != nil {
+			return key
+		}
+	}
+	return n
+}
+
+// child returns the value of a mapping key or the item of a sequence.
+func child(n *yaml.Node, p interface{}) *yaml.Node {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	switch p := p.(type) {
+	case string:
+		if key := mappingKey(n, p); key != nil {
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if n.Content[i] == key {
+					return n.Content[i+1]
+				}
+			}
+		}
+	case int:
+		if n.Kind == yaml.SequenceNode && p < len(n.Content) {
+			return n.Content[p]
+		}
+	}
+	return nil
+}
+
+func mappingKey(n *yaml.Node, name string) *yaml.Node {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == name {
+			return n.Content[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package configschema
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/dbsanity"
+	"github.com/dkoosis/lintkit/pkg/stale"
+)
+
+// TestSchemasMatchTypes keeps each schema's properties in step with the
+// fields of the Go type its file decodes into.
+func TestSchemasMatchTypes(t *testing.T) {
+	for name, typ := range map[string]struct {
+		v   any
+		tag string
+	}{
+		"project":           {config.Config{}, "yaml"},
+		"stale":             {stale.Config{}, "yaml"},
+		"filesize":          {struct{ Rules []config.FilesizeRule }{}, "yaml"},
+		"dbsanity":          {dbsanity.Config{}, "yaml"},
+		"dbsanity-baseline": {dbsanity.Baseline{}, "json"},
+		"dbsanity-history":  {dbsanity.History{}, "json"},
+	} {
+		k, ok := Lookup(name)
+		if !ok {
+			t.Fatalf("no kind %s", name)
+		}
+		var schema map[string]any
+		if err := json.Unmarshal(k.Schema(), &schema); err != nil {
+			t.Fatalf("%s: decode schema: %v", name, err)
+		}
+		compare(t, name, schema, reflect.TypeOf(typ.v), typ.tag)
+	}
+	if len(Kinds()) != 6 {
+		t.Fatalf("expected a test for every kind, have %d kinds", len(Kinds()))
+	}
+}
+
+func compare(t *testing.T, at string, schema map[string]any, typ reflect.Type, tag string) {
+	t.Helper()
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Struct:
+		if typ == reflect.TypeOf(time.Time{}) {
+			return // a string in the file
+		}
+		props, _ := schema["properties"].(map[string]any)
+		fields := structFields(typ, tag)
+		if got, want := sortedKeys(props), sortedKeys(fields); got != want {
+			t.Fatalf("%s: schema has properties %s, type has %s", at, got, want)
+		}
+		for name, ft := range fields {
+			compare(t, at+"."+name, props[name].(map[string]any), ft, tag)
+		}
+	case reflect.Slice:
+		items, ok := schema["items"].(map[string]any)
+		if !ok {
+			t.Fatalf("%s: schema has no items", at)
+		}
+		compare(t, at+"[]", items, typ.Elem(), tag)
+	case reflect.Map:
+		extra, ok := schema["additionalProperties"].(map[string]any)
+		if !ok {
+			t.Fatalf("%s: schema has no additionalProperties schema", at)
+		}
+		compare(t, at+".*", extra, typ.Elem(), tag)
+	}
+}
+
+// structFields returns the keys of typ's fields under tag, flattening
+// inline and embedded structs.
+func structFields(typ reflect.Type, tag string) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		if strings.Contains(opts, "inline") || (f.Anonymous && name == "") {
+			for k, v := range structFields(f.Type, tag) {
+				fields[k] = v
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+func sortedKeys[V any](m map[string]V) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
+
+func TestValidateReportsPositions(t *testing.T) {
+	for _, tc := range []struct{ kind, doc, want string }{
+		{
+			"project",
+			"wikifmt:\n  paths: [wiki]\n  pathz: [docs]\n",
+			`c.yml:3:3: wikifmt: unexpected property "pathz"`,
+		},
+		{
+			"stale",
+			"rules:\n  - derived: out.txt\n    source: in.txt\n    mode: hash\n",
+			`c.yml:4:11: rules: index 0: mode: "hash" is not one of "mtime"`,
+		},
+		{
+			"filesize",
+			"rules:\n  - pattern: '*.go'\n    max: 1.5\n",
+			`c.yml:3:10: rules: index 0: max: expected integer or string`,
+		},
+		{
+			"project",
+			"filesize:\n  rules:\n    - pattern: '*.go'\n      max: 12QB\n",
+			`c.yml:4:12: filesize: rules: index 0: max: "12QB" does not match pattern "^\\s*[0-9]+\\s*([KkMmGg]?[Bb])?\\s*$"`,
+		},
+		{
+			"dbsanity-baseline",
+			"{\n  \"tables\": {\"nodes\": \"ten\"}\n}\n",
+			`c.yml:2:23: tables: nodes: expected integer`,
+		},
+		{
+			"dbsanity",
+			"checks:\n  - query: SELECT 1\n",
+			`c.yml:2:5: checks: index 0: missing required properties: name`,
+		},
+	} {
+		k, _ := Lookup(tc.kind)
+		err := k.Validate("c.yml", []byte(tc.doc))
+		if err == nil || err.Error() != tc.want {
+			t.Fatalf("%s: expected %q, got %v", tc.kind, tc.want, err)
+		}
+	}
+}
+
+func TestCheckAcceptsExamples(t *testing.T) {
+	for kind, path := range map[string]string{
+		"project": filepath.Join("..", "..", "examples", "lintkit.yml"),
+		"stale":   filepath.Join("..", "..", "examples", "staleness.yml"),
+	} {
+		k, _ := Lookup(kind)
+		if err := k.Check(path); err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+	}
+
+	k, _ := Lookup("project")
+	if err := k.Validate("empty.yml", nil); err != nil {
+		t.Fatalf("empty project config: %v", err)
+	}
+}
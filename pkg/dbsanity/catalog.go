@@ -0,0 +1,35 @@
+package dbsanity
+
+import "github.com/dkoosis/lintkit/pkg/sarif"
+
+// ToolName is the SARIF driver name for dbsanity.
+const ToolName = "lintkit-dbsanity"
+
+var rules = []sarif.ReportingDescriptor{
+	{
+		ID:                   "db-row-drift",
+		ShortDescription:     sarif.Text("Table row count drifted from the baseline"),
+		FullDescription:      sarif.Text("A table's row count differs from the baseline by more than --threshold percent. Tables missing from the database count as a 100% drop."),
+		Help:                 sarif.Text("Investigate the data change or update the baseline if it is expected."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "error"},
+	},
+	{
+		ID:                   "db-check-info",
+		ShortDescription:     sarif.Text("Current value of a configured data check"),
+		FullDescription:      sarif.Text("Informational result reporting the scalar or breakdown value of each configured check."),
+		Help:                 sarif.Text("No action required."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "note"},
+	},
+	{
+		ID:                   "db-check-drift",
+		ShortDescription:     sarif.Text("Data check value changed since last week"),
+		FullDescription:      sarif.Text("A configured check returned a different value than the most recent snapshot from a previous ISO week in the history file."),
+		Help:                 sarif.Text("Confirm the change is expected."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"},
+	},
+}
+
+// Rules returns the rule catalog for dbsanity.
+func Rules() []sarif.ReportingDescriptor {
+	return append([]sarif.ReportingDescriptor(nil), rules...)
+}
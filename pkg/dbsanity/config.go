@@ -1,10 +1,10 @@
 package dbsanity
 
 import (
-	"bufio"
 	"fmt"
-	"os"
 	"strings"
+
+	"github.com/dkoosis/lintkit/pkg/yamlconf"
 )
 
 // CheckType defines the kind of result a check produces.
@@ -29,98 +29,38 @@ type Config struct {
 	Checks []Check `yaml:"checks"`
 }
 
-// LoadConfig reads a YAML configuration file for data checks.
+// LoadConfig reads a YAML configuration file for data checks. Queries may
+// span lines as block scalars; unknown keys and invalid checks are
+// reported with their position in the file.
 func LoadConfig(path string) (Config, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return Config{}, err
-	}
-
-	return parseChecksConfig(string(data))
-}
-
-// parseChecksConfig parses YAML config without external dependencies.
-func parseChecksConfig(content string) (Config, error) {
-	scanner := bufio.NewScanner(strings.NewReader(content))
 	var cfg Config
-	var current *Check
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		trimmed := strings.TrimSpace(line)
-
-		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
-			continue
-		}
-
-		if trimmed == "checks:" {
-			continue
-		}
-
-		if strings.HasPrefix(trimmed, "-") {
-			if current != nil {
-				cfg.Checks = append(cfg.Checks, *current)
-			}
-			current = &Check{}
-			trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
-			if trimmed == "" {
-				continue
-			}
-			if err := populateCheckField(current, trimmed); err != nil {
-				return Config{}, err
-			}
-			continue
-		}
-
-		if current != nil {
-			if err := populateCheckField(current, trimmed); err != nil {
-				return Config{}, err
-			}
-		}
-	}
-
-	if current != nil {
-		cfg.Checks = append(cfg.Checks, *current)
-	}
-
-	if err := scanner.Err(); err != nil {
+	if err := yamlconf.Load(path, &cfg); err != nil {
 		return Config{}, err
 	}
-
-	// Validate and set defaults
-	for i := range cfg.Checks {
-		if cfg.Checks[i].Name == "" {
-			return Config{}, fmt.Errorf("check at index %d missing name", i)
-		}
-		if cfg.Checks[i].Query == "" {
-			return Config{}, fmt.Errorf("check %q missing query", cfg.Checks[i].Name)
-		}
-		if cfg.Checks[i].Type == "" {
-			cfg.Checks[i].Type = CheckTypeScalar
-		}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
 	}
-
 	return cfg, nil
 }
 
-func populateCheckField(check *Check, line string) error {
-	parts := strings.SplitN(line, ":", 2)
-	if len(parts) != 2 {
-		return fmt.Errorf("invalid check line: %s", line)
-	}
-
-	key := strings.TrimSpace(parts[0])
-	val := strings.TrimSpace(parts[1])
-	val = strings.Trim(val, "\"")
-
-	switch key {
-	case "name":
-		check.Name = val
-	case "query":
-		check.Query = val
-	case "type":
-		check.Type = CheckType(val)
+// Validate checks that every check has a name, a query, and a known type,
+// defaulting the type to CheckTypeScalar.
+func (c *Config) Validate() error {
+	for i := range c.Checks {
+		check := &c.Checks[i]
+		if check.Name == "" {
+			return fmt.Errorf("check at index %d missing name", i)
+		}
+		if strings.TrimSpace(check.Query) == "" {
+			return fmt.Errorf("check %q missing query", check.Name)
+		}
+		switch check.Type {
+		case "":
+			check.Type = CheckTypeScalar
+		case CheckTypeScalar, CheckTypeBreakdown:
+		default:
+			return fmt.Errorf("check %q: unknown type %q (want %s or %s)", check.Name, check.Type, CheckTypeScalar, CheckTypeBreakdown)
+		}
 	}
-
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"math"
 	"os"
 	"os/exec"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -48,7 +49,8 @@ func CheckDatabase(ctx context.Context, dbPath string, baseline Baseline, thresh
 	}
 
 	var results []sarif.Result
-	for table, baselineCount := range baseline.Tables {
+	for _, table := range sortedKeys(baseline.Tables) {
+		baselineCount := baseline.Tables[table]
 		currentCount, ok := existingTables[table]
 		missing := !ok
 		if !missing {
@@ -79,13 +81,24 @@ func CheckDatabase(ctx context.Context, dbPath string, baseline Baseline, thresh
 						},
 					},
 				},
-			})
+			}.WithFingerprint(table))
 		}
 	}
 
 	return results, nil
 }
 
+// sortedKeys returns the keys of m in lexical order so results are emitted
+// deterministically.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func listTables(ctx context.Context, dbPath string) (map[string]int64, error) {
 	cmd := exec.CommandContext(ctx, "sqlite3", dbPath, "SELECT name FROM sqlite_master WHERE type='table' AND name NOT LIKE 'sqlite_%';")
 	output, err := cmd.Output()
@@ -139,13 +152,14 @@ func percentageDiff(baseline, current int64) float64 {
 func BuildLog(results []sarif.Result) *sarif.Log {
 	log := sarif.NewLog()
 	run := sarif.Run{
-		Tool: sarif.Tool{Driver: sarif.Driver{Name: "lintkit-dbsanity"}},
+		Tool: sarif.Tool{Driver: sarif.Driver{Name: ToolName, Rules: Rules()}},
 	}
 
 	if len(results) > 0 {
 		run.Results = results
 	}
 
+	run.IndexRules()
 	log.Runs = append(log.Runs, run)
 	return log
 }
@@ -235,9 +249,11 @@ func executeBreakdownCheck(ctx context.Context, dbPath, query string) (CheckResu
 func CompareWithHistory(dbPath string, current map[string]CheckResult, history *History, currentWeek string) []sarif.Result {
 	var results []sarif.Result
 
+	names := sortedKeys(current)
+
 	// Always emit informational results for current values
-	for name, result := range current {
-		results = append(results, buildInfoResult(dbPath, name, result))
+	for _, name := range names {
+		results = append(results, buildInfoResult(dbPath, name, current[name]))
 	}
 
 	// Compare with last week if available
@@ -246,7 +262,8 @@ func CompareWithHistory(dbPath string, current map[string]CheckResult, history *
 		return results
 	}
 
-	for name, currentResult := range current {
+	for _, name := range names {
+		currentResult := current[name]
 		prevResult, ok := lastWeek.Results[name]
 		if !ok {
 			continue
@@ -263,8 +280,8 @@ func buildInfoResult(dbPath, checkName string, result CheckResult) sarif.Result
 	var msg string
 	if result.Breakdown != nil {
 		parts := make([]string, 0, len(result.Breakdown))
-		for k, v := range result.Breakdown {
-			parts = append(parts, fmt.Sprintf("%s=%d", k, v))
+		for _, k := range sortedKeys(result.Breakdown) {
+			parts = append(parts, fmt.Sprintf("%s=%d", k, result.Breakdown[k]))
 		}
 		msg = fmt.Sprintf("[%s] %s", checkName, strings.Join(parts, ", "))
 	} else {
@@ -284,14 +301,15 @@ func buildInfoResult(dbPath, checkName string, result CheckResult) sarif.Result
 				},
 			},
 		},
-	}
+	}.WithFingerprint(checkName)
 }
 
 func compareSingleCheck(dbPath, checkName string, current, previous CheckResult, prevWeek string) []sarif.Result {
 	var results []sarif.Result
 
 	if current.Breakdown != nil && previous.Breakdown != nil {
-		for key, currVal := range current.Breakdown {
+		for _, key := range sortedKeys(current.Breakdown) {
+			currVal := current.Breakdown[key]
 			prevVal := previous.Breakdown[key]
 			if currVal != prevVal {
 				delta := currVal - prevVal
@@ -313,12 +331,13 @@ func compareSingleCheck(dbPath, checkName string, current, previous CheckResult,
 							},
 						},
 					},
-				})
+				}.WithFingerprint(checkName, key))
 			}
 		}
 
 		// Check for keys that existed before but don't now
-		for key, prevVal := range previous.Breakdown {
+		for _, key := range sortedKeys(previous.Breakdown) {
+			prevVal := previous.Breakdown[key]
 			if _, ok := current.Breakdown[key]; !ok {
 				msg := fmt.Sprintf("[%s] %s: %d → 0 (removed since %s)", checkName, key, prevVal, prevWeek)
 				results = append(results, sarif.Result{
@@ -334,7 +353,7 @@ func compareSingleCheck(dbPath, checkName string, current, previous CheckResult,
 							},
 						},
 					},
-				})
+				}.WithFingerprint(checkName, key))
 			}
 		}
 	} else if current.Scalar != previous.Scalar {
@@ -357,7 +376,7 @@ func compareSingleCheck(dbPath, checkName string, current, previous CheckResult,
 					},
 				},
 			},
-		})
+		}.WithFingerprint(checkName))
 	}
 
 	return results
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -110,3 +112,41 @@ func createTable(t *testing.T, dbPath string, name string, rows int) {
 		}
 	}
 }
+
+func TestLoadConfigBlockScalarQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checks.yaml")
+	content := `checks:
+  - name: orphans
+    query: |
+      SELECT COUNT(*)
+      FROM nodes
+      WHERE parent IS NULL
+  - name: by_kind
+    type: breakdown
+    query: SELECT kind, COUNT(*) FROM nodes GROUP BY kind
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if len(cfg.Checks) != 2 {
+		t.Fatalf("expected 2 checks, got %d", len(cfg.Checks))
+	}
+	if cfg.Checks[0].Query != "SELECT COUNT(*)\nFROM nodes\nWHERE parent IS NULL\n" || cfg.Checks[0].Type != CheckTypeScalar {
+		t.Fatalf("unexpected first check: %+v", cfg.Checks[0])
+	}
+	if cfg.Checks[1].Type != CheckTypeBreakdown {
+		t.Fatalf("unexpected second check: %+v", cfg.Checks[1])
+	}
+
+	if err := os.WriteFile(path, []byte("checks:\n  - name: x\n    query: SELECT 1\n    kind: scalar\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if _, err := LoadConfig(path); err == nil || !strings.Contains(err.Error(), path+`:4:5: unknown key "kind"`) {
+		t.Fatalf("expected positioned unknown key error, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package dbschema
+
+import "github.com/dkoosis/lintkit/pkg/sarif"
+
+// ToolName is the SARIF driver name for dbschema.
+const ToolName = "lintkit-dbschema"
+
+var rules = []sarif.ReportingDescriptor{
+	{
+		ID:                   "db-schema-missing-table",
+		ShortDescription:     sarif.Text("Expected table is missing"),
+		FullDescription:      sarif.Text("A table declared in the expected DDL does not exist in the database."),
+		Help:                 sarif.Text("Run the missing migration or remove the table from the expected schema."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "error"},
+	},
+	{
+		ID:                   "db-schema-missing-column",
+		ShortDescription:     sarif.Text("Expected column is missing"),
+		FullDescription:      sarif.Text("A column declared in the expected DDL does not exist on the database table."),
+		Help:                 sarif.Text("Run the missing migration or remove the column from the expected schema."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "error"},
+	},
+	{
+		ID:                   "db-schema-type-mismatch",
+		ShortDescription:     sarif.Text("Column type differs from the expected DDL"),
+		FullDescription:      sarif.Text("A column exists in both schemas but its declared type differs (compared case-insensitively)."),
+		Help:                 sarif.Text("Align the column type in the database or in the expected schema."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"},
+	},
+	{
+		ID:                   "db-schema-extra-column",
+		ShortDescription:     sarif.Text("Column is not in the expected DDL"),
+		FullDescription:      sarif.Text("The database table has a column that the expected DDL does not declare."),
+		Help:                 sarif.Text("Add the column to the expected schema or drop it from the database."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"},
+	},
+	{
+		ID:                   "db-schema-extra-table",
+		ShortDescription:     sarif.Text("Table is not in the expected DDL"),
+		FullDescription:      sarif.Text("The database contains a table that the expected DDL does not declare."),
+		Help:                 sarif.Text("Add the table to the expected schema or drop it from the database."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"},
+	},
+}
+
+// Rules returns the rule catalog for dbschema.
+func Rules() []sarif.ReportingDescriptor {
+	return append([]sarif.ReportingDescriptor(nil), rules...)
+}
@@ -18,7 +18,7 @@ func ToSARIF(dbPath string, findings []Result) []sarif.Result {
 					Region:           &sarif.Region{StartLine: 1},
 				},
 			}},
-		})
+		}.WithFingerprint(f.Subject))
 	}
 	return results
 }
@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"sort"
 	"strings"
 	"unsafe"
 )
@@ -242,42 +243,57 @@ type Result struct {
 	RuleID string
 	Level  string
 	Text   string
+	// Subject names the drifting object, e.g. "table" or "table.column".
+	Subject string
 }
 
+// diffSchemas reports drift table by table and column by column in name
+// order, so the findings come out the same on every run.
 func diffSchemas(expected, actual map[string]Table) []Result {
 	var results []Result
 
-	for name, exp := range expected {
+	for _, name := range sortedKeys(expected) {
+		exp := expected[name]
 		act, ok := actual[name]
 		if !ok {
-			results = append(results, Result{RuleID: "db-schema-missing-table", Level: "error", Text: fmt.Sprintf("Missing table '%s'", name)})
+			results = append(results, Result{RuleID: "db-schema-missing-table", Level: "error", Text: fmt.Sprintf("Missing table '%s'", name), Subject: name})
 			continue
 		}
 
-		for col, expType := range exp.Columns {
+		for _, col := range sortedKeys(exp.Columns) {
+			expType := exp.Columns[col]
 			actType, ok := act.Columns[col]
 			if !ok {
-				results = append(results, Result{RuleID: "db-schema-missing-column", Level: "error", Text: fmt.Sprintf("Missing column '%s.%s'", name, col)})
+				results = append(results, Result{RuleID: "db-schema-missing-column", Level: "error", Text: fmt.Sprintf("Missing column '%s.%s'", name, col), Subject: name + "." + col})
 				continue
 			}
 
 			if expType != "" && actType != "" && !compareTypes(expType, actType) {
-				results = append(results, Result{RuleID: "db-schema-type-mismatch", Level: "warning", Text: fmt.Sprintf("Type mismatch for column '%s.%s': expected %s, found %s", name, col, expType, actType)})
+				results = append(results, Result{RuleID: "db-schema-type-mismatch", Level: "warning", Text: fmt.Sprintf("Type mismatch for column '%s.%s': expected %s, found %s", name, col, expType, actType), Subject: name + "." + col})
 			}
 		}
 
-		for col := range act.Columns {
+		for _, col := range sortedKeys(act.Columns) {
 			if _, ok := exp.Columns[col]; !ok {
-				results = append(results, Result{RuleID: "db-schema-extra-column", Level: "warning", Text: fmt.Sprintf("Extra column '%s.%s'", name, col)})
+				results = append(results, Result{RuleID: "db-schema-extra-column", Level: "warning", Text: fmt.Sprintf("Extra column '%s.%s'", name, col), Subject: name + "." + col})
 			}
 		}
 	}
 
-	for name := range actual {
+	for _, name := range sortedKeys(actual) {
 		if _, ok := expected[name]; !ok {
-			results = append(results, Result{RuleID: "db-schema-extra-table", Level: "warning", Text: fmt.Sprintf("Extra table '%s'", name)})
+			results = append(results, Result{RuleID: "db-schema-extra-table", Level: "warning", Text: fmt.Sprintf("Extra table '%s'", name), Subject: name})
 		}
 	}
 
 	return results
 }
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
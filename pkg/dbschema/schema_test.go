@@ -118,3 +118,29 @@ func createDB(t *testing.T, stmts []string) string {
 
 	return dbPath
 }
+
+func TestCompareSchemas_ReportsInNameOrder(t *testing.T) {
+	expected := map[string]Table{
+		"b": {Name: "b", Columns: map[string]string{"z": "TEXT", "y": "TEXT", "x": "TEXT"}},
+		"a": {Name: "a", Columns: map[string]string{"id": "INTEGER"}},
+		"c": {Name: "c", Columns: map[string]string{"id": "INTEGER"}},
+	}
+	actual := map[string]Table{
+		"b": {Name: "b", Columns: map[string]string{"x": "INTEGER", "w": "TEXT", "v": "TEXT"}},
+		"e": {Name: "e"},
+		"d": {Name: "d"},
+	}
+	want := []string{"a", "b.x", "b.y", "b.z", "b.v", "b.w", "c", "d", "e"}
+
+	for i := 0; i < 20; i++ {
+		findings := CompareSchemas(expected, actual)
+		if len(findings) != len(want) {
+			t.Fatalf("expected %d findings, got %v", len(want), findings)
+		}
+		for j, f := range findings {
+			if f.Subject != want[j] {
+				t.Fatalf("finding %d: expected %s, got %s (%v)", j, want[j], f.Subject, findings)
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package docsprawl
+
+import "github.com/dkoosis/lintkit/pkg/sarif"
+
+// ToolName is the SARIF driver name for docsprawl.
+const ToolName = "lintkit-docsprawl"
+
+var rules = []sarif.ReportingDescriptor{
+	{
+		ID:                   "doc-readme-too-large",
+		ShortDescription:     sarif.Text("README exceeds the configured line budget"),
+		FullDescription:      sarif.Text("A README.md file is longer than --max-readme lines. Oversized READMEs bury the essentials and tend to accumulate stale detail."),
+		Help:                 sarif.Text("Move reference material into dedicated documents and link to them from the README."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"},
+	},
+	{
+		ID:                   "doc-too-many-files",
+		ShortDescription:     sarif.Text("Directory holds too many markdown files"),
+		FullDescription:      sarif.Text("A directory contains more markdown files than --max-files allows, which usually signals unstructured documentation sprawl."),
+		Help:                 sarif.Text("Group related documents into subdirectories or consolidate overlapping files."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"},
+	},
+	{
+		ID:                   "doc-orphan",
+		ShortDescription:     sarif.Text("Document is not reachable from a root README"),
+		FullDescription:      sarif.Text("No chain of relative markdown links leads from a README.md at a scanned root to this document, so readers are unlikely to find it."),
+		Help:                 sarif.Text("Link the document from a README or an index page, or delete it if it is obsolete."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "note"},
+	},
+	{
+		ID:                   "doc-duplicate",
+		ShortDescription:     sarif.Text("Documents are near-duplicates"),
+		FullDescription:      sarif.Text("Two documents share at least --duplicate-cutoff of their five-word shingles (Jaccard similarity), so they are likely copies that will drift apart."),
+		Help:                 sarif.Text("Merge the documents into one and link to it from both places."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"},
+	},
+}
+
+// Rules returns the rule catalog for docsprawl.
+func Rules() []sarif.ReportingDescriptor {
+	return append([]sarif.ReportingDescriptor(nil), rules...)
+}
@@ -15,13 +15,21 @@ import (
 	"strings"
 
 	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/walk"
 )
 
 // Config controls docsprawl checks.
 type Config struct {
-	MaxReadmeLines  int
-	MaxFilesPerDir  int
-	DuplicateCutoff float64
+	MaxReadmeLines  int     `yaml:"max_readme_lines"`
+	MaxFilesPerDir  int     `yaml:"max_files_per_dir"`
+	DuplicateCutoff float64 `yaml:"duplicate_cutoff"`
+	// Walk controls how roots are traversed.
+	Walk walk.Options `yaml:"-"`
+}
+
+// DefaultConfig returns the thresholds used when none are configured.
+func DefaultConfig() Config {
+	return Config{MaxReadmeLines: 500, MaxFilesPerDir: 10, DuplicateCutoff: 0.9}
 }
 
 // Result encapsulates analysis output.
@@ -40,7 +48,7 @@ func Run(roots []string, cfg Config) (*Result, error) {
 	if cfg.DuplicateCutoff <= 0 || cfg.DuplicateCutoff > 1 {
 		return nil, fmt.Errorf("duplicate cutoff must be in (0,1]")
 	}
-	docs, dirCounts, err := collectDocs(roots)
+	docs, dirCounts, err := collectDocs(roots, cfg.Walk)
 	if err != nil {
 		return nil, err
 	}
@@ -52,10 +60,7 @@ func Run(roots []string, cfg Config) (*Result, error) {
 	results = append(results, checkDuplicates(docs, cfg.DuplicateCutoff)...)
 
 	log := sarif.NewLog()
-	log.Runs = append(log.Runs, sarif.Run{
-		Tool:    sarif.Tool{Driver: sarif.Driver{Name: "lintkit-docsprawl"}},
-		Results: results,
-	})
+	log.Runs = append(log.Runs, sarif.NewRun(sarif.Driver{Name: ToolName, Rules: Rules()}, results))
 
 	return &Result{Log: log}, nil
 }
@@ -72,22 +77,32 @@ func Command() *flag.FlagSet {
 
 // RunCLI parses flags and executes the command, writing SARIF to w.
 func RunCLI(fs *flag.FlagSet, args []string, w io.Writer) error {
-	maxReadme := fs.Int("max-readme", 500, "maximum allowed README lines")
-	maxFiles := fs.Int("max-files", 10, "maximum markdown files per directory")
-	duplicateCutoff := fs.Float64("duplicate-cutoff", 0.9, "similarity threshold for near-duplicates (0-1]")
-	if err := fs.Parse(args); err != nil {
+	res, err := RunFlags(fs, args, DefaultConfig(), nil)
+	if err != nil {
 		return err
 	}
+	return res.Encode(w)
+}
+
+// RunFlags parses flags and executes the command, returning the result
+// without encoding it. Flags override the supplied defaults, and
+// defaultRoots are scanned when no ROOT arguments are given.
+func RunFlags(fs *flag.FlagSet, args []string, defaults Config, defaultRoots []string) (*Result, error) {
+	maxReadme := fs.Int("max-readme", defaults.MaxReadmeLines, "maximum allowed README lines")
+	maxFiles := fs.Int("max-files", defaults.MaxFilesPerDir, "maximum markdown files per directory")
+	duplicateCutoff := fs.Float64("duplicate-cutoff", defaults.DuplicateCutoff, "similarity threshold for near-duplicates (0-1]")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
 	roots := fs.Args()
 	if len(roots) == 0 {
-		return errors.New("at least one ROOT must be specified")
+		roots = defaultRoots
 	}
-	cfg := Config{MaxReadmeLines: *maxReadme, MaxFilesPerDir: *maxFiles, DuplicateCutoff: *duplicateCutoff}
-	res, err := Run(roots, cfg)
-	if err != nil {
-		return err
+	if len(roots) == 0 {
+		return nil, errors.New("at least one ROOT must be specified")
 	}
-	return res.Encode(w)
+	cfg := Config{MaxReadmeLines: *maxReadme, MaxFilesPerDir: *maxFiles, DuplicateCutoff: *duplicateCutoff}
+	return Run(roots, cfg)
 }
 
 // Encode writes the SARIF log to the writer as indented JSON.
@@ -113,18 +128,12 @@ type Doc struct {
 	Root        string
 }
 
-func collectDocs(roots []string) (map[string]*Doc, map[string]int, error) {
+func collectDocs(roots []string, opts walk.Options) (map[string]*Doc, map[string]int, error) {
 	docs := map[string]*Doc{}
 	dirCounts := map[string]int{}
 	for _, root := range roots {
 		root = filepath.Clean(root)
-		err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
-			if err != nil {
-				return err
-			}
-			if d.IsDir() {
-				return nil
-			}
+		err := walk.Walk(root, opts, func(path string, d os.DirEntry) error {
 			if !isMarkdown(d.Name()) {
 				return nil
 			}
@@ -200,7 +209,7 @@ func checkReadmeSize(docs map[string]*Doc, maxLines int) []sarif.Result {
 				Level:     "warning",
 				Message:   sarif.Message{Text: fmt.Sprintf("README exceeds %d lines (%d)", maxLines, doc.Lines)},
 				Locations: []sarif.Location{locationForFile(doc.Path, 1)},
-			})
+			}.WithFingerprint())
 		}
 	}
 	return results
@@ -215,7 +224,7 @@ func checkDirFileCounts(dirCounts map[string]int, maxFiles int) []sarif.Result {
 				Level:     "warning",
 				Message:   sarif.Message{Text: fmt.Sprintf("directory %s has %d markdown files (max %d)", dir, count, maxFiles)},
 				Locations: []sarif.Location{locationForFile(dir, 0)},
-			})
+			}.WithFingerprint())
 		}
 	}
 	return results
@@ -251,7 +260,7 @@ func checkOrphans(docs map[string]*Doc) []sarif.Result {
 				Level:     "note",
 				Message:   sarif.Message{Text: fmt.Sprintf("document is not reachable from a root README: %s", filepath.Base(path))},
 				Locations: []sarif.Location{locationForFile(path, 1)},
-			})
+			}.WithFingerprint())
 		}
 	}
 	return results
@@ -281,21 +290,35 @@ func checkDuplicates(docs map[string]*Doc, cutoff float64) []sarif.Result {
 			b := docs[paths[j]]
 			sim := similarity(a.Shingles, b.Shingles)
 			if sim >= cutoff {
+				// Name the duplicate relative to its root, so the message and
+				// fingerprint do not depend on how the root was written.
+				name := rootRelative(b)
 				results = append(results, sarif.Result{
-					RuleID:  "doc-duplicate",
-					Level:   "warning",
-					Message: sarif.Message{Text: fmt.Sprintf("documents appear nearly duplicate (similarity %.2f)", sim)},
-					Locations: []sarif.Location{
-						locationForFile(a.Path, 1),
-						locationForFile(b.Path, 1),
+					RuleID: "doc-duplicate",
+					Level:  "warning",
+					Message: sarif.Message{
+						Text:      "document appears nearly duplicate of {0} (similarity {1})",
+						Arguments: []string{name, fmt.Sprintf("%.2f", sim)},
 					},
-				})
+					Locations:        []sarif.Location{locationForFile(a.Path, 1)},
+					RelatedLocations: []sarif.Location{sarif.Related(1, b.Path, 1, "near-duplicate document")},
+				}.WithFingerprint(name))
 			}
 		}
 	}
 	return results
 }
 
+// rootRelative returns the slash-separated path of doc below the root it
+// was collected from.
+func rootRelative(doc *Doc) string {
+	rel, err := filepath.Rel(doc.Root, doc.Path)
+	if err != nil {
+		return filepath.ToSlash(doc.Path)
+	}
+	return filepath.ToSlash(rel)
+}
+
 func buildShingles(content string) map[string]struct{} {
 	tokens := tokenize(content)
 	const size = 5
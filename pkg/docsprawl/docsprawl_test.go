@@ -72,6 +72,59 @@ func TestDuplicateDetection(t *testing.T) {
 	if !hasRule(res.Log, "doc-duplicate") {
 		t.Fatalf("expected duplicate warning")
 	}
+	for _, r := range res.Log.Runs[0].Results {
+		if r.RuleID != "doc-duplicate" {
+			continue
+		}
+		if len(r.Locations) != 1 || len(r.RelatedLocations) != 1 {
+			t.Fatalf("expected one primary and one related location, got %+v", r)
+		}
+		if primary, related := r.PrimaryURI(), r.RelatedLocations[0].PhysicalLocation.ArtifactLocation.URI; filepath.Base(primary) != "one.md" || filepath.Base(related) != "two.md" {
+			t.Fatalf("unexpected locations: %s, %s", primary, related)
+		}
+	}
+}
+
+func TestDuplicateFingerprintsDoNotDependOnPathForm(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "docs", "one.md"), "Shared content across docs with only minor changes.")
+	writeFile(t, filepath.Join(root, "docs", "two.md"), "Shared content across docs with only minor change.")
+
+	// The same docs linted as `docs` and `./docs` from the root, as `.`
+	// from docs, and by absolute path, with the root as the source root.
+	forms := []struct{ dir, arg, root string }{
+		{root, "docs", "."},
+		{root, "./docs", "."},
+		{filepath.Join(root, "docs"), ".", ".."},
+		{root, filepath.Join(root, "docs"), root},
+	}
+	want := sarif.Fingerprint("doc-duplicate", "docs/one.md", "two.md")
+	for i, f := range forms {
+		t.Chdir(f.dir)
+		res, err := Run([]string{f.arg}, Config{MaxReadmeLines: 50, MaxFilesPerDir: 10, DuplicateCutoff: 0.6})
+		if err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		if err := sarif.Relativize(res.Log, f.root); err != nil {
+			t.Fatalf("Relativize: %v", err)
+		}
+		found := false
+		for _, r := range res.Log.Runs[0].Results {
+			if r.RuleID != "doc-duplicate" {
+				continue
+			}
+			found = true
+			if got := r.PartialFingerprints[sarif.FingerprintKey]; got != want {
+				t.Fatalf("form %d (%s): fingerprint %s, want %s", i, f.arg, got, want)
+			}
+			if got := r.Message.Arguments[0]; got != "two.md" {
+				t.Fatalf("form %d (%s): message names %q", i, f.arg, got)
+			}
+		}
+		if !found {
+			t.Fatalf("form %d (%s): expected duplicate warning", i, f.arg)
+		}
+	}
 }
 
 func hasRule(log *sarif.Log, rule string) bool {
@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/walk"
 )
 
 const (
@@ -31,6 +32,9 @@ type FileMetric struct {
 // Analyzer encapsulates rule evaluation and SARIF emission.
 type Analyzer struct {
 	rules []Rule
+
+	// Walk controls how directories are traversed.
+	Walk walk.Options
 }
 
 // NewAnalyzer creates an analyzer for the provided rules.
@@ -39,39 +43,42 @@ func NewAnalyzer(rules []Rule) *Analyzer {
 }
 
 // Analyze walks the provided paths (or "." if empty), evaluates rules, and
-// returns a SARIF log with the findings.
+// returns a SARIF log with the findings sorted by path.
 func (a *Analyzer) Analyze(paths []string) (*sarif.Log, error) {
-	if len(paths) == 0 {
-		paths = []string{"."}
-	}
-
-	metrics, err := collectMetrics(paths, a.needsLineCounts())
+	var results []sarif.Result
+	err := a.Stream(paths, func(r sarif.Result) error {
+		results = append(results, r)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].PrimaryURI() < results[j].PrimaryURI() })
 
-	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Path < metrics[j].Path })
+	log := sarif.NewLog()
+	log.Runs = append(log.Runs, sarif.NewRun(sarif.Driver{Name: ToolName, Rules: Rules()}, results))
+	return log, nil
+}
 
-	run := sarif.Run{
-		Tool: sarif.Tool{Driver: sarif.Driver{Name: "lintkit-filesize"}},
+// Stream walks the provided paths (or "." if empty) like Analyze but passes
+// each finding to emit as soon as its file is measured, in walk order, so
+// memory stays flat however many files there are. It stops at the first
+// error emit returns.
+func (a *Analyzer) Stream(paths []string, emit func(sarif.Result) error) error {
+	if len(paths) == 0 {
+		paths = []string{"."}
 	}
 
-	for _, m := range metrics {
+	return walkMetrics(paths, a.Walk, a.needsLineCounts(), func(m FileMetric) error {
 		rule := a.matchRule(m.Path)
 		if rule == nil {
-			run.Results = append(run.Results, infoResult(m))
-			continue
+			return emit(infoResult(m))
 		}
-
-		over, result := evaluateRule(*rule, m)
-		if over {
-			run.Results = append(run.Results, result)
+		if over, result := evaluateRule(*rule, m); over {
+			return emit(result)
 		}
-	}
-
-	log := sarif.NewLog()
-	log.Runs = append(log.Runs, run)
-	return log, nil
+		return nil
+	})
 }
 
 func (a *Analyzer) matchRule(path string) *Rule {
@@ -92,46 +99,41 @@ func (a *Analyzer) needsLineCounts() bool {
 	return len(a.rules) == 0 // metrics mode should include line counts when possible
 }
 
-func collectMetrics(paths []string, includeLines bool) ([]FileMetric, error) {
-	var metrics []FileMetric
+// walkMetrics measures every file under paths and passes it to fn.
+func walkMetrics(paths []string, opts walk.Options, includeLines bool, fn func(FileMetric) error) error {
 	wd, err := os.Getwd()
 	if err != nil {
-		return nil, fmt.Errorf("getwd: %w", err)
+		return fmt.Errorf("getwd: %w", err)
 	}
 	for _, p := range paths {
 		info, err := os.Stat(p)
 		if err != nil {
-			return nil, fmt.Errorf("stat %s: %w", p, err)
+			return fmt.Errorf("stat %s: %w", p, err)
 		}
 
 		if info.IsDir() {
-			err = filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
-				if err != nil {
-					return err
-				}
-				if d.IsDir() {
-					return nil
-				}
+			err = walk.Walk(p, opts, func(path string, _ fs.DirEntry) error {
 				metric, err := measureFile(path, wd, includeLines)
 				if err != nil {
 					return err
 				}
-				metrics = append(metrics, metric)
-				return nil
+				return fn(metric)
 			})
 			if err != nil {
-				return nil, err
+				return err
 			}
 			continue
 		}
 
 		metric, err := measureFile(p, wd, includeLines)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if err := fn(metric); err != nil {
+			return err
 		}
-		metrics = append(metrics, metric)
 	}
-	return metrics, nil
+	return nil
 }
 
 func measureFile(path, workdir string, includeLines bool) (FileMetric, error) {
@@ -172,7 +174,7 @@ func evaluateRule(rule Rule, metric FileMetric) (bool, sarif.Result) {
 			Locations: []sarif.Location{{
 				PhysicalLocation: sarif.PhysicalLocation{ArtifactLocation: sarif.ArtifactLocation{URI: metric.Path}},
 			}},
-		}
+		}.WithFingerprint()
 	}
 
 	if rule.MaxLines != nil && metric.Lines != nil && *metric.Lines > *rule.MaxLines {
@@ -183,7 +185,7 @@ func evaluateRule(rule Rule, metric FileMetric) (bool, sarif.Result) {
 			Locations: []sarif.Location{{
 				PhysicalLocation: sarif.PhysicalLocation{ArtifactLocation: sarif.ArtifactLocation{URI: metric.Path}},
 			}},
-		}
+		}.WithFingerprint()
 	}
 
 	return false, sarif.Result{}
@@ -202,7 +204,7 @@ func infoResult(metric FileMetric) sarif.Result {
 		Locations: []sarif.Location{{
 			PhysicalLocation: sarif.PhysicalLocation{ArtifactLocation: sarif.ArtifactLocation{URI: metric.Path}},
 		}},
-	}
+	}.WithFingerprint()
 }
 
 func matchPath(path, pattern string) bool {
@@ -2,9 +2,13 @@ package filesize
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/walk"
 )
 
 func TestEvaluateRuleBytes(t *testing.T) {
@@ -16,9 +20,13 @@ func TestEvaluateRuleBytes(t *testing.T) {
 	}
 
 	rule := Rule{Pattern: "*.bin", MaxBytes: ptrInt64(1024)}
-	metrics, err := collectMetrics([]string{tempDir}, false)
+	var metrics []FileMetric
+	err := walkMetrics([]string{tempDir}, walk.Options{}, false, func(m FileMetric) error {
+		metrics = append(metrics, m)
+		return nil
+	})
 	if err != nil {
-		t.Fatalf("collectMetrics: %v", err)
+		t.Fatalf("walkMetrics: %v", err)
 	}
 
 	if len(metrics) != 1 {
@@ -55,4 +63,26 @@ func TestAnalyzeEmitsSarif(t *testing.T) {
 	}
 }
 
+func TestStreamStopsAtEmitError(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte("x\n"), 0o644); err != nil {
+			t.Fatalf("write temp file: %v", err)
+		}
+	}
+
+	stop := errors.New("stop")
+	var seen []string
+	err := NewAnalyzer(nil).Stream([]string{tempDir}, func(r sarif.Result) error {
+		seen = append(seen, r.PrimaryURI())
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected emit error, got %v", err)
+	}
+	if len(seen) != 1 || filepath.Base(seen[0]) != "a.txt" {
+		t.Fatalf("expected a single metric for a.txt, got %v", seen)
+	}
+}
+
 func ptrInt64(v int64) *int64 { return &v }
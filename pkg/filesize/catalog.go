@@ -0,0 +1,28 @@
+package filesize
+
+import "github.com/dkoosis/lintkit/pkg/sarif"
+
+// ToolName is the SARIF driver name for filesize.
+const ToolName = "lintkit-filesize"
+
+var rules = []sarif.ReportingDescriptor{
+	{
+		ID:                   ruleIDBudget,
+		ShortDescription:     sarif.Text("File exceeds its size budget"),
+		FullDescription:      sarif.Text("The file is larger than the max bytes or max lines allowed by the first matching rule in the rules file."),
+		Help:                 sarif.Text("Split the file or raise its budget in the rules file."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"},
+	},
+	{
+		ID:                   ruleIDMetrics,
+		ShortDescription:     sarif.Text("File size metrics"),
+		FullDescription:      sarif.Text("Informational byte and line counts for files that no rule covers."),
+		Help:                 sarif.Text("No action required; add a rule to enforce a budget."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "note"},
+	},
+}
+
+// Rules returns the rule catalog for filesize.
+func Rules() []sarif.ReportingDescriptor {
+	return append([]sarif.ReportingDescriptor(nil), rules...)
+}
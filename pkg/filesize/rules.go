@@ -3,9 +3,10 @@ package filesize
 import (
 	"errors"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
+
+	"github.com/dkoosis/lintkit/pkg/yamlconf"
 )
 
 // Rule describes a single filesize constraint.
@@ -15,10 +16,15 @@ type Rule struct {
 	MaxLines *int
 }
 
-// ruleSpec mirrors the on-disk structure.
+// ruleSpec mirrors an entry of the rules file.
 type ruleSpec struct {
-	Pattern string
-	Max     interface{}
+	Pattern string      `yaml:"pattern"`
+	Max     interface{} `yaml:"max"`
+}
+
+// rulesFile is the root of the rules file.
+type rulesFile struct {
+	Rules []ruleSpec `yaml:"rules"`
 }
 
 // LoadRules reads rules from the provided path. If the path is empty, an empty
@@ -28,21 +34,16 @@ func LoadRules(path string) ([]Rule, error) {
 		return nil, nil
 	}
 
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("read rules: %w", err)
-	}
-
-	specs, err := parseRuleSpecs(string(data))
-	if err != nil {
+	var file rulesFile
+	if err := yamlconf.Load(path, &file); err != nil {
 		return nil, err
 	}
 
 	var rules []Rule
-	for i, spec := range specs {
+	for i, spec := range file.Rules {
 		rule, err := parseRuleSpec(spec)
 		if err != nil {
-			return nil, fmt.Errorf("rule %d: %w", i, err)
+			return nil, fmt.Errorf("%s: rule %d: %w", path, i, err)
 		}
 		rules = append(rules, rule)
 	}
@@ -50,6 +51,13 @@ func LoadRules(path string) ([]Rule, error) {
 	return rules, nil
 }
 
+// ParseRule builds a rule from a glob pattern and a max value, using the same
+// interpretation as the rules file: a unit suffix means bytes, a bare integer
+// means lines.
+func ParseRule(pattern string, max interface{}) (Rule, error) {
+	return parseRuleSpec(ruleSpec{Pattern: pattern, Max: max})
+}
+
 func parseRuleSpec(spec ruleSpec) (Rule, error) {
 	if strings.TrimSpace(spec.Pattern) == "" {
 		return Rule{}, errors.New("pattern is required")
@@ -138,82 +146,3 @@ func parseByteString(s string) (int64, bool) {
 	}
 	return 0, false
 }
-
-// parseRuleSpecs implements a small YAML subset parser for the rule file. The
-// accepted structure mirrors the README example and common "rules:" lists.
-func parseRuleSpecs(content string) ([]ruleSpec, error) {
-	lines := strings.Split(content, "\n")
-	var specs []ruleSpec
-	var current *ruleSpec
-	inRules := false
-
-	flush := func() {
-		if current != nil {
-			specs = append(specs, *current)
-			current = nil
-		}
-	}
-
-	for _, raw := range lines {
-		line := strings.TrimSpace(raw)
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-
-		if !inRules {
-			if line == "rules:" {
-				inRules = true
-				continue
-			}
-			continue
-		}
-
-		if strings.HasPrefix(line, "-") {
-			flush()
-			current = &ruleSpec{}
-			line = strings.TrimSpace(strings.TrimPrefix(line, "-"))
-			if line == "" {
-				continue
-			}
-			key, val, ok := splitKeyValue(line)
-			if !ok {
-				return nil, fmt.Errorf("invalid rule line: %s", raw)
-			}
-			assignRuleField(current, key, val)
-			continue
-		}
-
-		if current == nil {
-			return nil, fmt.Errorf("unexpected content outside rule item: %s", raw)
-		}
-
-		key, val, ok := splitKeyValue(line)
-		if !ok {
-			return nil, fmt.Errorf("invalid rule line: %s", raw)
-		}
-		assignRuleField(current, key, val)
-	}
-
-	flush()
-	return specs, nil
-}
-
-func splitKeyValue(line string) (string, string, bool) {
-	parts := strings.SplitN(line, ":", 2)
-	if len(parts) != 2 {
-		return "", "", false
-	}
-	key := strings.TrimSpace(parts[0])
-	val := strings.TrimSpace(parts[1])
-	val = strings.Trim(val, "\"'")
-	return key, val, true
-}
-
-func assignRuleField(spec *ruleSpec, key, val string) {
-	switch key {
-	case "pattern":
-		spec.Pattern = val
-	case "max":
-		spec.Max = val
-	}
-}
@@ -0,0 +1,182 @@
+package fix
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change.
+const contextLines = 3
+
+// diffOp is one line of an edit script: ' ' kept, '-' removed, '+' added.
+type diffOp struct {
+	kind byte
+	text string // including its newline, if any
+}
+
+// UnifiedDiff writes the differences between old and new in unified
+// format under the given file names. Nothing is written when they are
+// equal.
+func UnifiedDiff(w io.Writer, from, to string, old, new []byte) error {
+	ops := diffLines(splitLines(string(old)), splitLines(string(new)))
+	hunks := groupHunks(ops)
+	if len(hunks) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "--- %s\n+++ %s\n", from, to); err != nil {
+		return err
+	}
+	for _, h := range hunks {
+		if err := writeHunk(w, ops, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitLines splits s after each newline; a final line without one is kept.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines computes a shortest edit script from a to b with Myers'
+// algorithm.
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+
+search:
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// Walk the trace backwards from (n, m), recording the path taken.
+	var ops []diffOp
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			ops = append(ops, diffOp{' ', a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				ops = append(ops, diffOp{'+', b[y-1]})
+			} else {
+				ops = append(ops, diffOp{'-', a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+// hunk is a range of ops [start, end) shown together.
+type hunk struct {
+	start, end int
+}
+
+// groupHunks surrounds each change with context, merging changes whose
+// context would overlap.
+func groupHunks(ops []diffOp) []hunk {
+	var hunks []hunk
+	for i, op := range ops {
+		if op.kind == ' ' {
+			continue
+		}
+		start, end := max(0, i-contextLines), min(len(ops), i+contextLines+1)
+		if n := len(hunks); n > 0 && start <= hunks[n-1].end {
+			hunks[n-1].end = end
+			continue
+		}
+		hunks = append(hunks, hunk{start: start, end: end})
+	}
+	return hunks
+}
+
+func writeHunk(w io.Writer, ops []diffOp, h hunk) error {
+	// Line numbers before the hunk in each file.
+	oldLine, newLine := 0, 0
+	for _, op := range ops[:h.start] {
+		if op.kind != '+' {
+			oldLine++
+		}
+		if op.kind != '-' {
+			newLine++
+		}
+	}
+	oldLen, newLen := 0, 0
+	for _, op := range ops[h.start:h.end] {
+		if op.kind != '+' {
+			oldLen++
+		}
+		if op.kind != '-' {
+			newLen++
+		}
+	}
+	if _, err := fmt.Fprintf(w, "@@ -%s +%s @@\n", hunkRange(oldLine, oldLen), hunkRange(newLine, newLen)); err != nil {
+		return err
+	}
+	for _, op := range ops[h.start:h.end] {
+		text := op.text
+		if !strings.HasSuffix(text, "\n") {
+			text += "\n\\ No newline at end of file\n"
+		}
+		if _, err := fmt.Fprintf(w, "%c%s", op.kind, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hunkRange formats a hunk's start and length as diff(1) does: an empty
+// range names the line before it and a length of one is omitted.
+func hunkRange(before, length int) string {
+	switch length {
+	case 0:
+		return fmt.Sprintf("%d,0", before)
+	case 1:
+		return fmt.Sprintf("%d", before+1)
+	}
+	return fmt.Sprintf("%d,%d", before+1, length)
+}
@@ -0,0 +1,375 @@
+// Package fix applies the fixes linters attach to SARIF results.
+//
+// Every fix is resolved against the current file contents before anything
+// is written, so a plan either applies whole or reports why it cannot. A
+// fix overlapping one accepted earlier is skipped in its entirety.
+package fix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// Edit is the planned outcome for one file.
+type Edit struct {
+	Path   string
+	Old    []byte
+	New    []byte // nil when Delete is set
+	Delete bool
+}
+
+// Plan is the set of edits produced by the fixes of a log.
+type Plan struct {
+	Edits []Edit
+	// Applied lists the descriptions of the fixes included in Edits.
+	Applied []string
+	// Skipped lists the fixes left out, with the reason.
+	Skipped []string
+}
+
+// span is a replacement resolved to byte offsets within a file.
+type span struct {
+	start, end int
+	text       string
+}
+
+// NewPlan resolves the fixes of every active result in log against the
+// files they change. Artifact locations are resolved with
+// sarif.Run.LocalPath.
+func NewPlan(log *sarif.Log) (*Plan, error) {
+	p := &Plan{}
+	contents := map[string][]byte{}
+	spans := map[string][]span{}
+	deleted := map[string]bool{}
+	var order []string
+	seen := map[string]bool{}
+
+	load := func(path string) ([]byte, error) {
+		if data, ok := contents[path]; ok {
+			return data, nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		contents[path] = data
+		order = append(order, path)
+		return data, nil
+	}
+
+	for _, run := range log.Runs {
+		for _, r := range run.Results {
+			if !r.IsActive() {
+				continue
+			}
+			for _, f := range r.Fixes {
+				key, err := json.Marshal(f)
+				if err != nil {
+					return nil, err
+				}
+				if seen[string(key)] {
+					continue
+				}
+				seen[string(key)] = true
+
+				desc := describe(r, f)
+				resolved, reason, err := resolve(&run, f, load)
+				if err != nil {
+					return nil, err
+				}
+				if reason == "" {
+					reason = conflict(resolved, spans, deleted)
+				}
+				if reason != "" {
+					p.Skipped = append(p.Skipped, fmt.Sprintf("%s: %s", desc, reason))
+					continue
+				}
+				for path, ss := range resolved {
+					if ss == nil {
+						deleted[path] = true
+						continue
+					}
+					spans[path] = append(spans[path], ss...)
+				}
+				p.Applied = append(p.Applied, desc)
+			}
+		}
+	}
+
+	sort.Strings(order)
+	for _, path := range order {
+		old := contents[path]
+		switch {
+		case deleted[path]:
+			p.Edits = append(p.Edits, Edit{Path: path, Old: old, Delete: true})
+		case len(spans[path]) > 0:
+			p.Edits = append(p.Edits, Edit{Path: path, Old: old, New: splice(old, spans[path])})
+		}
+	}
+	return p, nil
+}
+
+func describe(r sarif.Result, f sarif.Fix) string {
+	desc := r.RuleID
+	if f.Description != nil && f.Description.Text != "" {
+		desc += ": " + f.Description.Text
+	}
+	if uri := r.PrimaryURI(); uri != "" {
+		desc = uri + ": " + desc
+	}
+	return desc
+}
+
+// resolve converts a fix's changes to byte spans per file; a nil slice
+// marks a deletion. A non-empty reason means the fix cannot be applied.
+func resolve(run *sarif.Run, f sarif.Fix, load func(string) ([]byte, error)) (map[string][]span, string, error) {
+	out := map[string][]span{}
+	for _, change := range f.ArtifactChanges {
+		path := run.LocalPath(change.ArtifactLocation)
+		data, err := load(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, path + " no longer exists", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		if change.DeletesArtifact() {
+			out[path] = nil
+			continue
+		}
+		for _, rep := range change.Replacements {
+			start, end, ok := offsets(data, rep.DeletedRegion)
+			if !ok {
+				return nil, fmt.Sprintf("region outside %s", path), nil
+			}
+			s := span{start: start, end: end}
+			if rep.InsertedContent != nil {
+				s.text = rep.InsertedContent.Text
+			}
+			out[path] = append(out[path], s)
+		}
+	}
+	return out, "", nil
+}
+
+// conflict explains why resolved overlaps fixes accepted so far, or
+// returns "".
+func conflict(resolved map[string][]span, accepted map[string][]span, deleted map[string]bool) string {
+	for path, ss := range resolved {
+		if deleted[path] {
+			return path + " is deleted by another fix"
+		}
+		if ss == nil && len(accepted[path]) > 0 {
+			return path + " is edited by another fix"
+		}
+		for _, s := range ss {
+			for _, a := range accepted[path] {
+				if overlaps(s, a) {
+					return "overlaps another fix in " + path
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// overlaps reports whether two spans touch the same bytes. Insertions at
+// the same offset do not overlap; an insertion strictly inside a deleted
+// range does.
+func overlaps(a, b span) bool {
+	if max(a.start, b.start) < min(a.end, b.end) {
+		return true
+	}
+	inside := func(pos int, s span) bool { return s.start < pos && pos < s.end }
+	return (a.start == a.end && inside(a.start, b)) || (b.start == b.end && inside(b.start, a))
+}
+
+// splice applies non-overlapping spans to data. Insertions at the same
+// offset keep the order in which they were accepted.
+func splice(data []byte, spans []span) []byte {
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	var out []byte
+	pos := 0
+	for _, s := range spans {
+		out = append(out, data[pos:s.start]...)
+		out = append(out, s.text...)
+		pos = max(pos, s.end)
+	}
+	return append(out, data[pos:]...)
+}
+
+// offsets converts a SARIF region to byte offsets in data. Lines and
+// columns are one-based, columns count UTF-16 code units, and a missing
+// end column means the end of the line, excluding its newline.
+func offsets(data []byte, r sarif.Region) (int, int, bool) {
+	if r.ByteOffset != nil {
+		start, end := *r.ByteOffset, *r.ByteOffset+r.ByteLength
+		return start, end, start >= 0 && end <= len(data) && start <= end
+	}
+	if r.StartLine < 1 {
+		return 0, 0, false
+	}
+	lineStarts := []int{0}
+	for i, b := range data {
+		if b == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+
+	// position resolves a line and column; column 0 means the line's end.
+	position := func(line, column int) (int, bool) {
+		if line > len(lineStarts) {
+			return 0, false
+		}
+		start := lineStarts[line-1]
+		end := len(data)
+		if line < len(lineStarts) {
+			end = lineStarts[line] - 1
+		}
+		text := strings.TrimSuffix(string(data[start:end]), "\r")
+		if column == 0 {
+			return start + len(text), true
+		}
+		units := 0
+		for i, r := range text {
+			if units >= column-1 {
+				return start + i, units == column-1
+			}
+			units += utf16.RuneLen(r)
+		}
+		return start + len(text), units == column-1
+	}
+
+	startColumn := r.StartColumn
+	if startColumn == 0 {
+		startColumn = 1
+	}
+	start, ok := position(r.StartLine, startColumn)
+	if !ok {
+		return 0, 0, false
+	}
+	endLine := r.EndLine
+	if endLine == 0 {
+		endLine = r.StartLine
+	}
+	end, ok := position(endLine, r.EndColumn)
+	if !ok || end < start {
+		return 0, 0, false
+	}
+	return start, end, true
+}
+
+// Apply writes the planned edits. Every new file content is written to a
+// temporary file beside its target first; only when all are written are
+// they renamed into place and deleted files removed.
+func (p *Plan) Apply() error {
+	temps := make([]string, len(p.Edits))
+	cleanup := func() {
+		for _, t := range temps {
+			if t != "" {
+				_ = os.Remove(t)
+			}
+		}
+	}
+	for i, e := range p.Edits {
+		if e.Delete {
+			continue
+		}
+		tmp, err := writeTemp(e.Path, e.New)
+		if err != nil {
+			cleanup()
+			return err
+		}
+		temps[i] = tmp
+	}
+	for i, e := range p.Edits {
+		if e.Delete {
+			continue
+		}
+		if err := os.Rename(temps[i], e.Path); err != nil {
+			cleanup()
+			return err
+		}
+		temps[i] = ""
+	}
+	for _, e := range p.Edits {
+		if e.Delete {
+			if err := os.Remove(e.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeTemp writes data to a new file in path's directory with path's
+// permissions and returns its name.
+func writeTemp(path string, data []byte) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".lintkit-fix-*")
+	if err != nil {
+		return "", err
+	}
+	name := f.Name()
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(name)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(name)
+		return "", err
+	}
+	if err := os.Chmod(name, info.Mode().Perm()); err != nil {
+		_ = os.Remove(name)
+		return "", err
+	}
+	return name, nil
+}
+
+// WriteDiff writes every edit as a unified diff.
+func (p *Plan) WriteDiff(w io.Writer) error {
+	for _, e := range p.Edits {
+		name := displayPath(e.Path)
+		from, to := "a/"+name, "b/"+name
+		if e.Delete {
+			to = "/dev/null"
+		}
+		if e.Delete && len(e.Old) == 0 {
+			// An empty file has no lines to diff; still show its removal.
+			if _, err := fmt.Fprintf(w, "--- %s\n+++ %s\n", from, to); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := UnifiedDiff(w, from, to, e.Old, e.New); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// displayPath returns path relative to the working directory when it lies
+// below it, with forward slashes.
+func displayPath(path string) string {
+	if filepath.IsAbs(path) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				path = rel
+			}
+		}
+	}
+	return filepath.ToSlash(path)
+}
@@ -0,0 +1,98 @@
+package fix
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func logOf(results ...sarif.Result) *sarif.Log {
+	log := sarif.NewLog()
+	log.Runs = []sarif.Run{{Tool: sarif.Tool{Driver: sarif.Driver{Name: "test"}}, Results: results}}
+	return log
+}
+
+func result(rule, uri string) sarif.Result {
+	return sarif.Result{RuleID: rule, Locations: []sarif.Location{{PhysicalLocation: sarif.PhysicalLocation{ArtifactLocation: sarif.ArtifactLocation{URI: uri}}}}}
+}
+
+func TestPlanAppliesFixes(t *testing.T) {
+	dir := t.TempDir()
+	page := filepath.Join(dir, "page.md")
+	backup := filepath.Join(dir, "page.md.bak")
+	writeFile(t, page, "---\ntitle: Héllo\ntags:\n  - API\n---\nbody\n")
+	writeFile(t, backup, "old")
+
+	tag := sarif.Region{StartLine: 4, StartColumn: 5, EndLine: 4, EndColumn: 8}
+	suppressed := result("r", page).WithFix("ignored", sarif.Insert(page, 1, "never\n"))
+	suppressed.Suppressions = []sarif.Suppression{{Kind: "inSource"}}
+	log := logOf(
+		result("tag", page).WithFix("lower", sarif.Replace(page, tag, "api")),
+		result("date", page).WithFix("date", sarif.Insert(page, 5, "date: 2024-01-01\n")),
+		result("title", page).WithFix("title", sarif.Replace(page, sarif.Region{StartLine: 2, StartColumn: 8}, "Hi")),
+		result("clash", page).WithFix("clash", sarif.Replace(page, sarif.Region{StartLine: 4, StartColumn: 7, EndLine: 4, EndColumn: 9}, "x")),
+		result("backup", backup).WithFix("delete", sarif.DeleteArtifact(backup, 3)),
+		suppressed,
+	)
+
+	plan, err := NewPlan(log)
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	if len(plan.Applied) != 4 || len(plan.Skipped) != 1 || !strings.Contains(plan.Skipped[0], "clash") {
+		t.Fatalf("unexpected plan: applied %v, skipped %v", plan.Applied, plan.Skipped)
+	}
+
+	var diff bytes.Buffer
+	if err := plan.WriteDiff(&diff); err != nil {
+		t.Fatalf("WriteDiff: %v", err)
+	}
+	for _, want := range []string{"-title: Héllo\n+title: Hi\n", "-  - API\n+  - api\n+date: 2024-01-01\n", "+++ /dev/null\n@@ -1 +0,0 @@\n-old\n\\ No newline at end of file\n"} {
+		if !strings.Contains(diff.String(), want) {
+			t.Fatalf("diff missing %q:\n%s", want, diff.String())
+		}
+	}
+
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if got, want := readFile(t, page), "---\ntitle: Hi\ntags:\n  - api\ndate: 2024-01-01\n---\nbody\n"; got != want {
+		t.Fatalf("unexpected page:\n%s", got)
+	}
+	if _, err := os.Stat(backup); !os.IsNotExist(err) {
+		t.Fatalf("expected backup to be deleted, got %v", err)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	old := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	new := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\n"
+	var buf bytes.Buffer
+	if err := UnifiedDiff(&buf, "a/x", "b/x", []byte(old), []byte(new)); err != nil {
+		t.Fatalf("UnifiedDiff: %v", err)
+	}
+	want := "--- a/x\n+++ b/x\n@@ -1,5 +1,5 @@\n a\n-b\n+B\n c\n d\n e\n@@ -8,3 +8,4 @@\n h\n i\n j\n+k\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected diff:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
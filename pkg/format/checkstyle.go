@@ -0,0 +1,63 @@
+package format
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// writeCheckstyle groups findings by file in Checkstyle XML. Findings
+// without a location are reported under the tool name.
+func writeCheckstyle(w io.Writer, log *sarif.Log) error {
+	doc := checkstyleReport{Version: "4.3"}
+	byFile := map[string]int{}
+	for _, f := range findings(log) {
+		name := f.path
+		if name == "" {
+			name = f.tool
+		}
+		i, ok := byFile[name]
+		if !ok {
+			i = len(doc.Files)
+			byFile[name] = i
+			doc.Files = append(doc.Files, checkstyleFile{Name: name})
+		}
+		doc.Files[i].Errors = append(doc.Files[i].Errors, checkstyleError{
+			Line:     f.line,
+			Column:   f.column,
+			Severity: checkstyleSeverity(f.level()),
+			Message:  f.result.Message.Formatted(),
+			Source:   f.tool + "." + f.result.RuleID,
+		})
+	}
+	return writeXML(w, doc)
+}
+
+func checkstyleSeverity(level string) string {
+	switch level {
+	case "error", "warning":
+		return level
+	default:
+		return "info"
+	}
+}
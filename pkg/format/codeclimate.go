@@ -0,0 +1,65 @@
+package format
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// codeClimateIssue is the subset of the Code Climate issue format read by
+// GitLab code quality reports.
+type codeClimateIssue struct {
+	Type        string              `json:"type"`
+	CheckName   string              `json:"check_name"`
+	Description string              `json:"description"`
+	Categories  []string            `json:"categories"`
+	Severity    string              `json:"severity"`
+	Fingerprint string              `json:"fingerprint"`
+	Location    codeClimateLocation `json:"location"`
+}
+
+type codeClimateLocation struct {
+	Path  string           `json:"path"`
+	Lines codeClimateLines `json:"lines"`
+}
+
+type codeClimateLines struct {
+	Begin int `json:"begin"`
+}
+
+// writeCodeClimate renders findings as a Code Climate JSON array.
+func writeCodeClimate(w io.Writer, log *sarif.Log) error {
+	issues := []codeClimateIssue{}
+	for _, f := range findings(log) {
+		line := f.line
+		if line == 0 {
+			line = 1
+		}
+		issues = append(issues, codeClimateIssue{
+			Type:        "issue",
+			CheckName:   f.result.RuleID,
+			Description: f.result.Message.Formatted(),
+			Categories:  []string{"Style"},
+			Severity:    codeClimateSeverity(f.level()),
+			Fingerprint: f.result.StableFingerprint(),
+			Location:    codeClimateLocation{Path: f.path, Lines: codeClimateLines{Begin: line}},
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(issues)
+}
+
+func codeClimateSeverity(level string) string {
+	switch level {
+	case "error":
+		return "critical"
+	case "warning":
+		return "major"
+	case "note":
+		return "minor"
+	default:
+		return "info"
+	}
+}
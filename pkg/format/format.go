@@ -0,0 +1,102 @@
+// Package format renders SARIF logs in the output formats supported by the
+// lintkit commands: SARIF itself, compact text, and several CI-native
+// report formats.
+//
+// Every format except SARIF reports only active results; suppressed and
+// absent baseline results are left out.
+package format
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// Default is the format used when none is requested.
+const Default = "sarif"
+
+// Formatter writes a SARIF log to w in a specific format.
+type Formatter func(w io.Writer, log *sarif.Log) error
+
+var formatters = map[string]Formatter{
+	"sarif":       writeSARIF,
+	"text":        writeText,
+	"github":      writeGitHub,
+	"junit":       writeJUnit,
+	"checkstyle":  writeCheckstyle,
+	"codeclimate": writeCodeClimate,
+	"json-lines":  writeJSONLines,
+}
+
+// Names returns the supported format names in lexical order.
+func Names() []string {
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the formatter registered under name.
+func Lookup(name string) (Formatter, error) {
+	f, ok := formatters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q (want %s)", name, strings.Join(Names(), ", "))
+	}
+	return f, nil
+}
+
+// Write renders log to w in the named format.
+func Write(w io.Writer, name string, log *sarif.Log) error {
+	f, err := Lookup(name)
+	if err != nil {
+		return err
+	}
+	return f(w, log)
+}
+
+func writeSARIF(w io.Writer, log *sarif.Log) error {
+	return sarif.NewEncoder(w).Encode(log)
+}
+
+// finding is an active result flattened with the tool that reported it.
+type finding struct {
+	tool   string
+	result sarif.Result
+	path   string
+	line   int
+	column int
+}
+
+// level returns the result level, defaulting to "warning" as SARIF does.
+func (f finding) level() string {
+	if f.result.Level == "" {
+		return "warning"
+	}
+	return f.result.Level
+}
+
+// findings returns the active results of every run in log order.
+func findings(log *sarif.Log) []finding {
+	var out []finding
+	for _, run := range log.Runs {
+		for _, r := range run.Results {
+			if !r.IsActive() {
+				continue
+			}
+			f := finding{tool: run.Tool.Driver.Name, result: r, path: r.PrimaryURI()}
+			if len(r.Locations) > 0 {
+				if region := r.Locations[0].PhysicalLocation.Region; region != nil {
+					f.line = region.StartLine
+					f.column = region.StartColumn
+				}
+			}
+			out = append(out, f)
+		}
+	}
+	return out
+}
@@ -0,0 +1,112 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+func sampleLog() *sarif.Log {
+	log := sarif.NewLog()
+	log.Runs = append(log.Runs, sarif.NewRun(sarif.Driver{Name: "lintkit-wikifmt"}, []sarif.Result{
+		{
+			RuleID:  "wiki-missing-title",
+			Level:   "error",
+			Message: sarif.Message{Text: "missing title, see docs"},
+			Locations: []sarif.Location{{PhysicalLocation: sarif.PhysicalLocation{
+				ArtifactLocation: sarif.ArtifactLocation{URI: "docs/a.md"},
+				Region:           &sarif.Region{StartLine: 3},
+			}}},
+		},
+		{
+			RuleID:       "wiki-tag-case",
+			Level:        "warning",
+			Message:      sarif.Message{Text: "suppressed"},
+			Suppressions: []sarif.Suppression{{Kind: "external"}},
+		},
+	}))
+	log.Runs = append(log.Runs, sarif.NewRun(sarif.Driver{Name: "lintkit-nobackups"}, nil))
+	return log
+}
+
+func render(t *testing.T, name string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Write(&buf, name, sampleLog()); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return buf.String()
+}
+
+func TestText(t *testing.T) {
+	got := render(t, "text")
+	want := "docs/a.md:3: error wiki-missing-title missing title, see docs\n"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestGitHub(t *testing.T) {
+	got := render(t, "github")
+	want := "::error file=docs/a.md,line=3,title=lintkit-wikifmt%3A wiki-missing-title::missing title, see docs\n"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestJUnit(t *testing.T) {
+	var doc junitSuites
+	if err := xml.Unmarshal([]byte(render(t, "junit")), &doc); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if doc.Tests != 2 || doc.Failures != 1 || len(doc.Suites) != 2 {
+		t.Fatalf("unexpected totals: %+v", doc)
+	}
+	if doc.Suites[1].Cases[0].Failure != nil {
+		t.Fatalf("expected passing case for tool without findings")
+	}
+}
+
+func TestCheckstyle(t *testing.T) {
+	var doc checkstyleReport
+	if err := xml.Unmarshal([]byte(render(t, "checkstyle")), &doc); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(doc.Files) != 1 || doc.Files[0].Name != "docs/a.md" || doc.Files[0].Errors[0].Severity != "error" {
+		t.Fatalf("unexpected report: %+v", doc)
+	}
+}
+
+func TestCodeClimate(t *testing.T) {
+	var issues []codeClimateIssue
+	if err := json.Unmarshal([]byte(render(t, "codeclimate")), &issues); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Severity != "critical" || issues[0].Fingerprint == "" || issues[0].Location.Lines.Begin != 3 {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+}
+
+func TestJSONLines(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(render(t, "json-lines")), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected one line, got %d", len(lines))
+	}
+	var got jsonLine
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got.Tool != "lintkit-wikifmt" || got.Path != "docs/a.md" || got.Line != 3 {
+		t.Fatalf("unexpected line: %+v", got)
+	}
+}
+
+func TestLookupRejectsUnknownFormat(t *testing.T) {
+	if _, err := Lookup("yaml"); err == nil {
+		t.Fatalf("expected unknown format error")
+	}
+}
@@ -0,0 +1,51 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// writeGitHub emits GitHub Actions workflow commands, which the runner
+// turns into annotations on the pull request.
+func writeGitHub(w io.Writer, log *sarif.Log) error {
+	for _, f := range findings(log) {
+		var props []string
+		if f.path != "" {
+			props = append(props, "file="+escapeProperty(f.path))
+			if f.line > 0 {
+				props = append(props, fmt.Sprintf("line=%d", f.line))
+			}
+			if f.column > 0 {
+				props = append(props, fmt.Sprintf("col=%d", f.column))
+			}
+		}
+		props = append(props, "title="+escapeProperty(f.tool+": "+f.result.RuleID))
+
+		if _, err := fmt.Fprintf(w, "::%s %s::%s\n", githubCommand(f.level()), strings.Join(props, ","), escapeData(f.result.Message.Formatted())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func githubCommand(level string) string {
+	switch level {
+	case "error":
+		return "error"
+	case "warning":
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+var (
+	dataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	propertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+func escapeData(s string) string     { return dataEscaper.Replace(s) }
+func escapeProperty(s string) string { return propertyEscaper.Replace(s) }
@@ -0,0 +1,42 @@
+package format
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// jsonLine is one finding in the json-lines format.
+type jsonLine struct {
+	Tool          string `json:"tool"`
+	RuleID        string `json:"ruleId"`
+	Level         string `json:"level"`
+	Message       string `json:"message"`
+	Path          string `json:"path,omitempty"`
+	Line          int    `json:"line,omitempty"`
+	Column        int    `json:"column,omitempty"`
+	Fingerprint   string `json:"fingerprint"`
+	BaselineState string `json:"baselineState,omitempty"`
+}
+
+// writeJSONLines writes one compact JSON object per finding.
+func writeJSONLines(w io.Writer, log *sarif.Log) error {
+	enc := json.NewEncoder(w)
+	for _, f := range findings(log) {
+		if err := enc.Encode(jsonLine{
+			Tool:          f.tool,
+			RuleID:        f.result.RuleID,
+			Level:         f.level(),
+			Message:       f.result.Message.Formatted(),
+			Path:          f.path,
+			Line:          f.line,
+			Column:        f.column,
+			Fingerprint:   f.result.StableFingerprint(),
+			BaselineState: f.result.BaselineState,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
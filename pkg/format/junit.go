@@ -0,0 +1,94 @@
+package format
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit renders one test suite per tool and one failed test case per
+// finding. A tool without findings gets a single passing case so that CI
+// still shows it ran.
+func writeJUnit(w io.Writer, log *sarif.Log) error {
+	var doc junitSuites
+	byTool := map[string]int{}
+	for _, run := range log.Runs {
+		name := run.Tool.Driver.Name
+		if _, ok := byTool[name]; !ok {
+			byTool[name] = len(doc.Suites)
+			doc.Suites = append(doc.Suites, junitSuite{Name: name})
+		}
+	}
+
+	for _, f := range findings(log) {
+		suite := &doc.Suites[byTool[f.tool]]
+		suite.Cases = append(suite.Cases, junitCase{
+			Name:      fmt.Sprintf("%s %s", f.result.RuleID, textPosition(f)),
+			Classname: f.tool,
+			File:      f.path,
+			Line:      f.line,
+			Failure: &junitFailure{
+				Message: f.result.Message.Formatted(),
+				Type:    f.level(),
+				Text:    fmt.Sprintf("%s: %s %s %s", textPosition(f), f.level(), f.result.RuleID, f.result.Message.Formatted()),
+			},
+		})
+		suite.Failures++
+	}
+
+	for i := range doc.Suites {
+		suite := &doc.Suites[i]
+		if len(suite.Cases) == 0 {
+			suite.Cases = append(suite.Cases, junitCase{Name: suite.Name, Classname: suite.Name})
+		}
+		suite.Tests = len(suite.Cases)
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+	}
+
+	return writeXML(w, doc)
+}
+
+// writeXML writes an indented XML document with its header.
+func writeXML(w io.Writer, doc interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,34 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// writeText prints one "path:line: level rule message" line per finding.
+// Findings without a location are attributed to their tool.
+func writeText(w io.Writer, log *sarif.Log) error {
+	for _, f := range findings(log) {
+		if _, err := fmt.Fprintf(w, "%s: %s %s %s\n", textPosition(f), f.level(), f.result.RuleID, f.result.Message.Formatted()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func textPosition(f finding) string {
+	if f.path == "" {
+		return f.tool
+	}
+	pos := f.path
+	if f.line > 0 {
+		pos += ":" + strconv.Itoa(f.line)
+		if f.column > 0 {
+			pos += ":" + strconv.Itoa(f.column)
+		}
+	}
+	return pos
+}
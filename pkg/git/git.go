@@ -0,0 +1,155 @@
+// Package git resolves file sets from the local git binary.
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Changes is a set of files reported by git, stored as absolute paths.
+type Changes struct {
+	files map[string]bool
+}
+
+// ChangedSince returns the files that differ between ref and the working
+// tree of the repository containing dir, excluding deletions.
+func ChangedSince(ctx context.Context, dir, ref string) (*Changes, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("empty git ref")
+	}
+	return diff(ctx, dir, ref)
+}
+
+// Staged returns the files added, copied, modified, or renamed in the index
+// of the repository containing dir.
+func Staged(ctx context.Context, dir string) (*Changes, error) {
+	return diff(ctx, dir, "--cached")
+}
+
+func diff(ctx context.Context, dir, target string) (*Changes, error) {
+	top, err := TopLevel(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	out, err := run(ctx, top, "diff", "--name-only", "-z", "--diff-filter=ACMRT", target, "--")
+	if err != nil {
+		return nil, err
+	}
+	c := &Changes{files: map[string]bool{}}
+	for _, name := range strings.Split(string(out), "\x00") {
+		if name != "" {
+			c.files[filepath.Join(top, filepath.FromSlash(name))] = true
+		}
+	}
+	return c, nil
+}
+
+// TopLevel returns the absolute root of the work tree containing dir.
+func TopLevel(ctx context.Context, dir string) (string, error) {
+	out, err := run(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Clean(strings.TrimSpace(string(out))), nil
+}
+
+// HooksDir returns the absolute hooks directory of the repository
+// containing dir, honouring core.hooksPath.
+func HooksDir(ctx context.Context, dir string) (string, error) {
+	out, err := run(ctx, dir, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	path := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(path) {
+		abs, err := filepath.Abs(filepath.Join(dir, path))
+		if err != nil {
+			return "", err
+		}
+		path = abs
+	}
+	return path, nil
+}
+
+// CheckoutIndex writes the content staged in the index of the repository
+// containing dir below dest, which must exist. The work tree is untouched,
+// so dest holds exactly what the next commit would contain.
+func CheckoutIndex(ctx context.Context, dir, dest string) error {
+	top, err := TopLevel(ctx, dir)
+	if err != nil {
+		return err
+	}
+	dest, err = filepath.Abs(dest)
+	if err != nil {
+		return err
+	}
+	_, err = run(ctx, top, "checkout-index", "--all", "--prefix="+dest+string(filepath.Separator))
+	return err
+}
+
+// Rebase returns the set with every path below from moved below to, such
+// as the files of a repository re-anchored in a CheckoutIndex copy. Paths
+// outside from are dropped.
+func (c *Changes) Rebase(from, to string) *Changes {
+	out := &Changes{files: make(map[string]bool, len(c.files))}
+	for p := range c.files {
+		rel, err := filepath.Rel(from, p)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		out.files[filepath.Join(to, rel)] = true
+	}
+	return out
+}
+
+// Contains reports whether path, absolute or relative to the working
+// directory, is in the set.
+func (c *Changes) Contains(path string) bool {
+	abs, err := filepath.Abs(strings.TrimPrefix(path, "file://"))
+	if err != nil {
+		return false
+	}
+	if c.files[abs] {
+		return true
+	}
+	// git reports real paths; the caller's may go through a symlink.
+	real, err := filepath.EvalSymlinks(abs)
+	return err == nil && c.files[real]
+}
+
+// Len returns the number of files in the set.
+func (c *Changes) Len() int {
+	return len(c.files)
+}
+
+// Paths returns the absolute paths in the set in lexical order.
+func (c *Changes) Paths() []string {
+	paths := make([]string, 0, len(c.files))
+	for p := range c.files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// run executes git in dir and returns its standard output. The error
+// includes git's standard error.
+func run(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
@@ -0,0 +1,109 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// initRepo creates a repository with one commit containing a.md and b.md.
+func initRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	gitCmd(t, dir, "init", "-q")
+	writeFile(t, filepath.Join(dir, "a.md"), "a\n")
+	writeFile(t, filepath.Join(dir, "b.md"), "b\n")
+	gitCmd(t, dir, "add", ".")
+	gitCmd(t, dir, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial")
+	return dir
+}
+
+func gitCmd(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestChangedSinceAndStaged(t *testing.T) {
+	dir := initRepo(t)
+	writeFile(t, filepath.Join(dir, "a.md"), "changed\n")
+	writeFile(t, filepath.Join(dir, "c.md"), "new\n")
+	gitCmd(t, dir, "add", "c.md")
+
+	ctx := context.Background()
+	changed, err := ChangedSince(ctx, dir, "HEAD")
+	if err != nil {
+		t.Fatalf("ChangedSince: %v", err)
+	}
+	if changed.Len() != 2 || !changed.Contains(filepath.Join(dir, "a.md")) || !changed.Contains(filepath.Join(dir, "c.md")) {
+		t.Fatalf("unexpected changed set: %v", changed.Paths())
+	}
+
+	staged, err := Staged(ctx, dir)
+	if err != nil {
+		t.Fatalf("Staged: %v", err)
+	}
+	if staged.Len() != 1 || !staged.Contains(filepath.Join(dir, "c.md")) {
+		t.Fatalf("unexpected staged set: %v", staged.Paths())
+	}
+
+	if _, err := ChangedSince(ctx, dir, "no-such-ref"); err == nil {
+		t.Fatalf("expected unknown ref to fail")
+	}
+}
+
+func TestCheckoutIndexUsesStagedContent(t *testing.T) {
+	dir := initRepo(t)
+	writeFile(t, filepath.Join(dir, "a.md"), "staged\n")
+	gitCmd(t, dir, "add", "a.md")
+	writeFile(t, filepath.Join(dir, "a.md"), "unstaged\n")
+
+	ctx := context.Background()
+	dest := t.TempDir()
+	if err := CheckoutIndex(ctx, dir, dest); err != nil {
+		t.Fatalf("CheckoutIndex: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dest, "a.md"))
+	if err != nil {
+		t.Fatalf("read snapshot: %v", err)
+	}
+	if string(data) != "staged\n" {
+		t.Fatalf("expected staged content, got %q", data)
+	}
+
+	staged, err := Staged(ctx, dir)
+	if err != nil {
+		t.Fatalf("Staged: %v", err)
+	}
+	top, err := TopLevel(ctx, dir)
+	if err != nil {
+		t.Fatalf("TopLevel: %v", err)
+	}
+	rebased := staged.Rebase(top, dest)
+	if rebased.Len() != 1 || !rebased.Contains(filepath.Join(dest, "a.md")) {
+		t.Fatalf("unexpected rebased set: %v", rebased.Paths())
+	}
+
+	hooks, err := HooksDir(ctx, dir)
+	if err != nil {
+		t.Fatalf("HooksDir: %v", err)
+	}
+	if filepath.Base(hooks) != "hooks" || !filepath.IsAbs(hooks) {
+		t.Fatalf("unexpected hooks dir %q", hooks)
+	}
+}
@@ -0,0 +1,23 @@
+package jsonl
+
+import "github.com/dkoosis/lintkit/pkg/sarif"
+
+// ToolName is the SARIF driver name for jsonl.
+const ToolName = "lintkit-jsonl"
+
+const ruleID = "jsonl-schema"
+
+var rules = []sarif.ReportingDescriptor{
+	{
+		ID:                   ruleID,
+		ShortDescription:     sarif.Text("JSONL line does not match the schema"),
+		FullDescription:      sarif.Text("A line is not valid JSON or does not satisfy the JSON Schema passed with --schema."),
+		Help:                 sarif.Text("Fix the reported line so it validates against the schema."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "error"},
+	},
+}
+
+// Rules returns the rule catalog for jsonl.
+func Rules() []sarif.ReportingDescriptor {
+	return append([]sarif.ReportingDescriptor(nil), rules...)
+}
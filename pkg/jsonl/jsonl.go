@@ -27,6 +27,25 @@ func NewValidator(schemaPath string) (*Validator, error) {
 	return &Validator{schema: schema}, nil
 }
 
+// ParseValidator compiles the JSON Schema document in data.
+func ParseValidator(data []byte) (*Validator, error) {
+	schema, err := parseSchema(data)
+	if err != nil {
+		return nil, fmt.Errorf("compile schema: %w", err)
+	}
+
+	return &Validator{schema: schema}, nil
+}
+
+// Validate checks a value decoded by encoding/json, or built from the same
+// types, against the schema. A mismatch is a *ValidationError.
+func (v *Validator) Validate(value interface{}) error {
+	if err := v.schema.validate(value); err != nil {
+		return err
+	}
+	return nil
+}
+
 // ValidateFile validates a JSONL file line by line and returns SARIF results for failures.
 func ValidateFile(path string, validator *Validator) ([]sarif.Result, error) {
 	file, err := os.Open(path)
@@ -49,12 +68,12 @@ func ValidateFile(path string, validator *Validator) ([]sarif.Result, error) {
 
 		var value interface{}
 		if err := json.Unmarshal([]byte(raw), &value); err != nil {
-			results = append(results, newResult(path, line, fmt.Sprintf("line %d: invalid JSON: %v", line, err)))
+			results = append(results, newResult(path, line, fmt.Sprintf("line %d: invalid JSON: %v", line, err)).WithFingerprint(raw))
 			continue
 		}
 
 		if err := validator.schema.validate(value); err != nil {
-			results = append(results, newResult(path, line, fmt.Sprintf("line %d: %v", line, err)))
+			results = append(results, newResult(path, line, fmt.Sprintf("line %d: %v", line, err)).WithFingerprint(raw))
 		}
 	}
 
@@ -67,7 +86,7 @@ func ValidateFile(path string, validator *Validator) ([]sarif.Result, error) {
 
 func newResult(path string, line int, message string) sarif.Result {
 	return sarif.Result{
-		RuleID: "jsonl-schema",
+		RuleID: ruleID,
 		Level:  "error",
 		Message: sarif.Message{
 			Text: message,
@@ -66,6 +66,50 @@ func TestNewValidatorInvalidSchema(t *testing.T) {
 	}
 }
 
+func TestValidatorKeywords(t *testing.T) {
+	validator, err := ParseValidator([]byte(`{
+  "type": "object",
+  "properties": {
+    "rules": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "max": {"type": ["integer", "string"], "pattern": "^[0-9]+(KB|MB)?$"},
+          "mode": {"type": "string", "enum": ["mtime"]}
+        },
+        "additionalProperties": false
+      }
+    },
+    "levels": {"type": "object", "additionalProperties": {"type": "string", "enum": ["off", "error"]}}
+  }
+}`))
+	if err != nil {
+		t.Fatalf("compile schema: %v", err)
+	}
+
+	for doc, want := range map[string]string{
+		`{"rules": [{"max": 10}, {"max": "10KB", "mode": "mtime"}], "levels": {"a": "off"}}`: "",
+		`{"rules": [{"max": 1.5}]}`:     "rules: index 0: max: expected integer or string",
+		`{"rules": [{"max": "12QB"}]}`:  `rules: index 0: max: "12QB" does not match pattern "^[0-9]+(KB|MB)?$"`,
+		`{"rules": [{"mode": "hash"}]}`: `rules: index 0: mode: "hash" is not one of "mtime"`,
+		`{"rules": [{"maxx": 1}]}`:      `rules: index 0: unexpected property "maxx"`,
+		`{"levels": {"a": "loud"}}`:     `levels: a: "loud" is not one of "off", "error"`,
+	} {
+		var value interface{}
+		if err := json.Unmarshal([]byte(doc), &value); err != nil {
+			t.Fatalf("decode %s: %v", doc, err)
+		}
+		err := validator.Validate(value)
+		switch {
+		case want == "" && err != nil:
+			t.Fatalf("%s: unexpected error %v", doc, err)
+		case want != "" && (err == nil || err.Error() != want):
+			t.Fatalf("%s: expected %q, got %v", doc, want, err)
+		}
+	}
+}
+
 func TestSarifEncoding(t *testing.T) {
 	result := newResult("file.jsonl", 5, "line 5: example")
 
@@ -1,30 +1,128 @@
 package jsonl
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
+	"reflect"
+	"regexp"
 	"strings"
 )
 
-// schemaDefinition represents a limited subset of JSON Schema used for validation.
+// schemaDefinition represents a limited subset of JSON Schema used for
+// validation: type (one name or a list), enum, pattern, required,
+// properties, additionalProperties (a boolean or a schema), and items.
+// Annotations such as title and description are accepted and ignored.
 type schemaDefinition struct {
-	Type                 string                       `json:"type"`
+	Type                 schemaTypes                  `json:"type"`
+	Enum                 []interface{}                `json:"enum"`
+	Pattern              *schemaPattern               `json:"pattern"`
 	Required             []string                     `json:"required"`
 	Properties           map[string]*schemaDefinition `json:"properties"`
-	AdditionalProperties *bool                        `json:"additionalProperties"`
+	AdditionalProperties *additionalProperties        `json:"additionalProperties"`
 	Items                *schemaDefinition            `json:"items"`
 }
 
+// schemaTypes is the "type" keyword: a single type name or a list of them.
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*t = schemaTypes{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return fmt.Errorf("type must be a string or a list of strings")
+	}
+	*t = many
+	return nil
+}
+
+// schemaPattern is the "pattern" keyword: a regular expression that string
+// values must match somewhere, as in JSON Schema, which does not anchor it.
+type schemaPattern struct {
+	*regexp.Regexp
+}
+
+func (p *schemaPattern) UnmarshalJSON(data []byte) error {
+	var expr string
+	if err := json.Unmarshal(data, &expr); err != nil {
+		return fmt.Errorf("pattern must be a string")
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("pattern: %w", err)
+	}
+	p.Regexp = re
+	return nil
+}
+
+// additionalProperties is the "additionalProperties" keyword: false to
+// forbid properties not listed in properties, or a schema they must match.
+type additionalProperties struct {
+	allowed bool
+	schema  *schemaDefinition
+}
+
+func (a *additionalProperties) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.allowed); err == nil {
+		return nil
+	}
+	a.allowed = true
+	return json.Unmarshal(data, &a.schema)
+}
+
+// ValidationError is a value that does not match a schema. Path leads from
+// the document root to the value: object keys as strings and array indexes
+// as ints.
+type ValidationError struct {
+	Path []interface{}
+	// Key is the offending property of the object at Path, for errors
+	// about a single property that is not allowed.
+	Key string
+	Msg string
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	for _, p := range e.Path {
+		switch p := p.(type) {
+		case int:
+			fmt.Fprintf(&b, "index %d: ", p)
+		default:
+			fmt.Fprintf(&b, "%v: ", p)
+		}
+	}
+	b.WriteString(e.Msg)
+	return b.String()
+}
+
+// invalid returns a ValidationError at the current value.
+func invalid(format string, args ...interface{}) *ValidationError {
+	return &ValidationError{Msg: fmt.Sprintf(format, args...)}
+}
+
+// within prefixes the path of err with elem, the key or index of the child
+// value that failed.
+func within(elem interface{}, err *ValidationError) *ValidationError {
+	err.Path = append([]interface{}{elem}, err.Path...)
+	return err
+}
+
 // compileSchema reads and parses a JSON Schema document.
 func compileSchema(path string) (*schemaDefinition, error) {
-	f, err := os.Open(path)
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is a user-supplied schema file
 	if err != nil {
 		return nil, err
 	}
-	defer func() { _ = f.Close() }()
+	return parseSchema(data)
+}
 
-	dec := json.NewDecoder(f)
+func parseSchema(data []byte) (*schemaDefinition, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
 
 	var schema schemaDefinition
 	if err := dec.Decode(&schema); err != nil {
@@ -34,79 +132,116 @@ func compileSchema(path string) (*schemaDefinition, error) {
 	return &schema, nil
 }
 
-func (s *schemaDefinition) validate(value interface{}) error {
-	switch s.Type {
-	case "object", "":
-		obj, ok := value.(map[string]interface{})
-		if !ok {
-			return fmt.Errorf("expected object")
+func (s *schemaDefinition) validate(value interface{}) *ValidationError {
+	types := s.Type
+	if len(types) == 0 {
+		types = schemaTypes{"object"}
+	}
+	for _, t := range types {
+		ok, known := matchesType(t, value)
+		if !known {
+			return invalid("unsupported schema type %q", t)
 		}
+		if ok {
+			return s.validateAs(t, value)
+		}
+	}
+	return invalid("expected %s", strings.Join(types, " or "))
+}
 
-		required := map[string]struct{}{}
-		for _, r := range s.Required {
-			required[r] = struct{}{}
+// matchesType reports whether value, as decoded by encoding/json, is of
+// the named JSON Schema type, and whether the name is one this package
+// supports.
+func matchesType(t string, value interface{}) (ok, known bool) {
+	switch t {
+	case "object":
+		_, ok = value.(map[string]interface{})
+	case "array":
+		_, ok = value.([]interface{})
+	case "string":
+		_, ok = value.(string)
+	case "boolean":
+		_, ok = value.(bool)
+	case "null":
+		ok = value == nil
+	case "number":
+		_, ok = value.(float64)
+	case "integer":
+		switch v := value.(type) {
+		case float64:
+			ok = v == float64(int64(v))
+		case int, int32, int64, uint, uint32, uint64:
+			ok = true // already integer
 		}
+	default:
+		return false, false
+	}
+	return ok, true
+}
+
+// validateAs checks value, already known to be of type t, against the rest
+// of the schema.
+func (s *schemaDefinition) validateAs(t string, value interface{}) *ValidationError {
+	switch t {
+	case "object":
+		obj := value.(map[string]interface{})
 
 		for key, val := range obj {
-			delete(required, key)
 			if propSchema, ok := s.Properties[key]; ok && propSchema != nil {
 				if err := propSchema.validate(val); err != nil {
-					return fmt.Errorf("%s: %w", key, err)
+					return within(key, err)
+				}
+				continue
+			}
+			switch extra := s.AdditionalProperties; {
+			case extra == nil:
+			case !extra.allowed:
+				err := invalid("unexpected property %q", key)
+				err.Key = key
+				return err
+			case extra.schema != nil:
+				if err := extra.schema.validate(val); err != nil {
+					return within(key, err)
 				}
-			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
-				return fmt.Errorf("unexpected property %q", key)
 			}
 		}
 
-		if len(required) > 0 {
-			missing := make([]string, 0, len(required))
-			for key := range required {
+		var missing []string
+		for _, key := range s.Required {
+			if _, ok := obj[key]; !ok {
 				missing = append(missing, key)
 			}
-			return fmt.Errorf("missing required properties: %s", strings.Join(missing, ", "))
-		}
-		return nil
-	case "string":
-		if _, ok := value.(string); !ok {
-			return fmt.Errorf("expected string")
-		}
-		return nil
-	case "integer":
-		switch v := value.(type) {
-		case float64:
-			if v != float64(int64(v)) {
-				return fmt.Errorf("expected integer")
-			}
-		case int, int32, int64, uint, uint32, uint64:
-			// already integer
-		default:
-			return fmt.Errorf("expected integer")
-		}
-		return nil
-	case "number":
-		if _, ok := value.(float64); !ok {
-			return fmt.Errorf("expected number")
 		}
-		return nil
-	case "boolean":
-		if _, ok := value.(bool); !ok {
-			return fmt.Errorf("expected boolean")
+		if len(missing) > 0 {
+			return invalid("missing required properties: %s", strings.Join(missing, ", "))
 		}
-		return nil
 	case "array":
-		arr, ok := value.([]interface{})
-		if !ok {
-			return fmt.Errorf("expected array")
-		}
 		if s.Items != nil {
-			for i, item := range arr {
+			for i, item := range value.([]interface{}) {
 				if err := s.Items.validate(item); err != nil {
-					return fmt.Errorf("index %d: %w", i, err)
+					return within(i, err)
 				}
 			}
 		}
-		return nil
-	default:
-		return fmt.Errorf("unsupported schema type %q", s.Type)
+	case "string":
+		if s.Pattern != nil && !s.Pattern.MatchString(value.(string)) {
+			return invalid("%q does not match pattern %q", value, s.Pattern)
+		}
+	}
+
+	if len(s.Enum) > 0 {
+		for _, allowed := range s.Enum {
+			if reflect.DeepEqual(allowed, value) {
+				return nil
+			}
+		}
+		want := make([]string, len(s.Enum))
+		for i, allowed := range s.Enum {
+			b, _ := json.Marshal(allowed)
+			want[i] = string(b)
+		}
+		got, _ := json.Marshal(value)
+		return invalid("%s is not one of %s", got, strings.Join(want, ", "))
 	}
+	return nil
 }
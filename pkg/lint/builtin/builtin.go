@@ -0,0 +1,95 @@
+// Package builtin adapts lintkit's bundled linters to the lint.Linter
+// interface, taking their defaults from the project configuration.
+package builtin
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// Register adds every bundled linter to reg. Linter options default to the
+// matching sections of cfg, which may be nil.
+func Register(reg *lint.Registry, cfg *config.Config) {
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	reg.MustRegister("Analyze markdown sprawl and emit SARIF", func() lint.Linter { return newDocsprawl(cfg) })
+	reg.MustRegister("Check SQLite row counts against baseline", func() lint.Linter { return newDbsanity(cfg) })
+	reg.MustRegister("Check wiki-style markdown files", func() lint.Linter { return newWikifmt(cfg) })
+	reg.MustRegister("Detect stale artifacts based on mtime rules", func() lint.Linter { return newStale(cfg) })
+	reg.MustRegister("Lint ORCA knowledge nugget JSONL files", func() lint.Linter { return newNuglint(cfg) })
+	reg.MustRegister("Check file sizes against budget rules", func() lint.Linter { return newFilesize(cfg) })
+	reg.MustRegister("Detect backup/temporary files", func() lint.Linter { return newNobackups(cfg) })
+	reg.MustRegister("Validate JSONL files against JSON Schema", func() lint.Linter { return newJSONL(cfg) })
+	reg.MustRegister("Compare SQLite schemas against expected DDL", func() lint.Linter { return newDbschema(cfg) })
+	reg.MustRegister("Check markdown reachability and placement", func() lint.Linter { return newMdsanity(cfg) })
+}
+
+// NewRegistry returns a registry holding every bundled linter.
+func NewRegistry(cfg *config.Config) *lint.Registry {
+	reg := lint.NewRegistry()
+	Register(reg, cfg)
+	return reg
+}
+
+// resultsOf flattens the results of every run in a log.
+func resultsOf(log *sarif.Log) []sarif.Result {
+	var results []sarif.Result
+	for _, run := range log.Runs {
+		results = append(results, run.Results...)
+	}
+	return results
+}
+
+// emitAll passes results to emit in order, stopping at the first error.
+func emitAll(results []sarif.Result, emit func(sarif.Result) error) error {
+	for _, r := range results {
+		if err := emit(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// databaseExts are the file extensions of SQLite databases.
+var databaseExts = []string{".sqlite", ".sqlite3", ".db"}
+
+// hasExt reports whether path has one of exts, ignoring case.
+func hasExt(path string, exts ...string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range exts {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// isFile reports whether path names file, which may be empty.
+func isFile(path, file string) bool {
+	return file != "" && filepath.Clean(path) == filepath.Clean(file)
+}
+
+// pathsOr returns paths when non-empty and fallback otherwise.
+func pathsOr(paths, fallback []string) []string {
+	if len(paths) > 0 {
+		return paths
+	}
+	return fallback
+}
+
+// withFiles returns paths followed by the non-empty files, for linters
+// that also read option files such as schemas.
+func withFiles(paths []string, files ...string) []string {
+	out := append([]string(nil), paths...)
+	for _, f := range files {
+		if f != "" {
+			out = append(out, f)
+		}
+	}
+	return out
+}
@@ -0,0 +1,170 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/dbsanity"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+type dbsanityLinter struct {
+	project   *config.Config
+	sec       *config.Dbsanity
+	baseline  string
+	threshold float64
+	checks    string
+	history   string
+	update    bool
+}
+
+func newDbsanity(project *config.Config) *dbsanityLinter {
+	sec := project.Dbsanity
+	if sec == nil {
+		sec = &config.Dbsanity{}
+	}
+	l := &dbsanityLinter{
+		project:   project,
+		sec:       sec,
+		baseline:  project.Resolve(sec.Baseline),
+		threshold: 20.0,
+		history:   project.Resolve(sec.History),
+	}
+	if sec.Threshold > 0 {
+		l.threshold = sec.Threshold
+	}
+	return l
+}
+
+func (l *dbsanityLinter) Name() string                       { return "dbsanity" }
+func (l *dbsanityLinter) ToolName() string                   { return dbsanity.ToolName }
+func (l *dbsanityLinter) Rules() []sarif.ReportingDescriptor { return dbsanity.Rules() }
+func (l *dbsanityLinter) MatchesInput(path string) bool {
+	return hasExt(path, databaseExts...) || isFile(path, l.baseline) || isFile(path, l.checks)
+}
+
+// Inputs leaves out the history file, which --update rewrites on every run.
+func (l *dbsanityLinter) Inputs(req lint.Request) []string {
+	return withFiles(pathsOr(req.Paths, l.project.ResolveAll(l.sec.Databases)), l.baseline, l.checks)
+}
+
+func (l *dbsanityLinter) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&l.baseline, "baseline", l.baseline, "Path to baseline JSON with expected table counts")
+	fs.Float64Var(&l.threshold, "threshold", l.threshold, "Percentage threshold for drift detection")
+	fs.StringVar(&l.checks, "config", "", "Path to YAML config for data checks")
+	fs.StringVar(&l.history, "history", l.history, "Path to history JSON file for WoW tracking")
+	fs.BoolVar(&l.update, "update", false, "Update history file with current results")
+
+	//nolint:errcheck // CLI usage output
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: lintkit dbsanity [--baseline counts.json | --config checks.yaml] DB...\n")
+		fmt.Fprintf(fs.Output(), "\nModes:\n")
+		fmt.Fprintf(fs.Output(), "  Legacy:  --baseline counts.json [--threshold PCT]\n")
+		fmt.Fprintf(fs.Output(), "  Checks:  --config checks.yaml [--history history.json] [--update]\n")
+		fs.PrintDefaults()
+	}
+}
+
+func (l *dbsanityLinter) Run(ctx context.Context, req lint.Request) ([]sarif.Result, error) {
+	var results []sarif.Result
+	err := l.Stream(ctx, req, func(r sarif.Result) error {
+		results = append(results, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Stream reports the findings of each database as soon as it is checked.
+func (l *dbsanityLinter) Stream(ctx context.Context, req lint.Request, emit func(sarif.Result) error) error {
+	dbPaths := pathsOr(req.Paths, l.project.ResolveAll(l.sec.Databases))
+	if len(dbPaths) == 0 {
+		return errors.New("at least one database path is required")
+	}
+
+	// Config-based mode
+	if l.checks != "" {
+		cfg, err := dbsanity.LoadConfig(l.checks)
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+		return l.runChecks(ctx, dbPaths, cfg, emit)
+	}
+	if len(l.sec.Checks) > 0 {
+		return l.runChecks(ctx, dbPaths, l.sec.Config, emit)
+	}
+
+	// Legacy baseline mode
+	if l.baseline == "" {
+		return errors.New("either --baseline or --config is required")
+	}
+
+	counts, err := dbsanity.LoadBaseline(l.baseline)
+	if err != nil {
+		return fmt.Errorf("failed to load baseline: %w", err)
+	}
+
+	for _, dbPath := range dbPaths {
+		found, err := dbsanity.CheckDatabase(ctx, dbPath, counts, l.threshold)
+		if err != nil {
+			return fmt.Errorf("checking %s: %w", dbPath, err)
+		}
+		if err := emitAll(found, emit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *dbsanityLinter) runChecks(ctx context.Context, dbPaths []string, cfg dbsanity.Config, emit func(sarif.Result) error) error {
+	var history dbsanity.History
+	if l.history != "" {
+		var err error
+		history, err = dbsanity.LoadHistory(l.history)
+		if err != nil {
+			return fmt.Errorf("load history: %w", err)
+		}
+	}
+
+	now := time.Now()
+	currentWeek := dbsanity.ISOWeek(now)
+
+	allCheckResults := make(map[string]dbsanity.CheckResult)
+
+	for _, dbPath := range dbPaths {
+		checkResults, err := dbsanity.RunChecks(ctx, dbPath, cfg)
+		if err != nil {
+			return fmt.Errorf("checks on %s: %w", dbPath, err)
+		}
+
+		for k, v := range checkResults {
+			allCheckResults[k] = v
+		}
+
+		results := dbsanity.CompareWithHistory(dbPath, checkResults, &history, currentWeek)
+		if err := emitAll(results, emit); err != nil {
+			return err
+		}
+	}
+
+	// Update history if requested
+	if l.update && l.history != "" {
+		history.AddSnapshot(dbsanity.Snapshot{
+			Timestamp: now,
+			Week:      currentWeek,
+			Results:   allCheckResults,
+		})
+		if err := dbsanity.SaveHistory(l.history, history); err != nil {
+			return fmt.Errorf("save history: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/dbschema"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+type dbschemaLinter struct {
+	project  *config.Config
+	sec      *config.Dbschema
+	expected string
+}
+
+func newDbschema(project *config.Config) *dbschemaLinter {
+	sec := project.Dbschema
+	if sec == nil {
+		sec = &config.Dbschema{}
+	}
+	return &dbschemaLinter{project: project, sec: sec, expected: project.Resolve(sec.Expected)}
+}
+
+func (l *dbschemaLinter) Name() string                       { return "dbschema" }
+func (l *dbschemaLinter) ToolName() string                   { return dbschema.ToolName }
+func (l *dbschemaLinter) Rules() []sarif.ReportingDescriptor { return dbschema.Rules() }
+func (l *dbschemaLinter) MatchesInput(path string) bool {
+	return hasExt(path, databaseExts...) || hasExt(path, ".sql")
+}
+
+func (l *dbschemaLinter) Inputs(req lint.Request) []string {
+	return withFiles(pathsOr(req.Paths, l.project.ResolveAll(l.sec.Databases)), l.expected)
+}
+
+func (l *dbschemaLinter) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&l.expected, "expected", l.expected, "Path to expected schema DDL file")
+	//nolint:errcheck // CLI usage output
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: lintkit dbschema --expected schema.sql DB...\n")
+		fs.PrintDefaults()
+	}
+}
+
+func (l *dbschemaLinter) Run(ctx context.Context, req lint.Request) ([]sarif.Result, error) {
+	if l.expected == "" {
+		return nil, errors.New("--expected is required")
+	}
+
+	dbPaths := pathsOr(req.Paths, l.project.ResolveAll(l.sec.Databases))
+	if len(dbPaths) == 0 {
+		return nil, errors.New("at least one database path is required")
+	}
+
+	expectedFile, err := os.Open(l.expected)
+	if err != nil {
+		return nil, fmt.Errorf("open expected schema: %w", err)
+	}
+	defer func() { _ = expectedFile.Close() }()
+
+	expected, err := dbschema.ParseExpectedSchema(expectedFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []sarif.Result
+	for _, dbPath := range dbPaths {
+		actual, err := dbschema.LoadActualSchema(ctx, dbPath)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, dbschema.ToSARIF(dbPath, dbschema.CompareSchemas(expected, actual))...)
+	}
+	return results, nil
+}
@@ -0,0 +1,95 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"slices"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/docsprawl"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+type docsprawlLinter struct {
+	cfg   docsprawl.Config
+	paths []string
+}
+
+func newDocsprawl(project *config.Config) *docsprawlLinter {
+	l := &docsprawlLinter{cfg: docsprawl.DefaultConfig()}
+	l.cfg.Walk = project.WalkOptions()
+	if sec := project.Docsprawl; sec != nil {
+		if sec.MaxReadmeLines > 0 {
+			l.cfg.MaxReadmeLines = sec.MaxReadmeLines
+		}
+		if sec.MaxFilesPerDir > 0 {
+			l.cfg.MaxFilesPerDir = sec.MaxFilesPerDir
+		}
+		if sec.DuplicateCutoff > 0 {
+			l.cfg.DuplicateCutoff = sec.DuplicateCutoff
+		}
+		l.paths = project.PathsOr(sec.Paths)
+	}
+	return l
+}
+
+func (l *docsprawlLinter) Name() string                       { return "docsprawl" }
+func (l *docsprawlLinter) ToolName() string                   { return docsprawl.ToolName }
+func (l *docsprawlLinter) Rules() []sarif.ReportingDescriptor { return docsprawl.Rules() }
+func (l *docsprawlLinter) MatchesInput(path string) bool      { return hasExt(path, ".md") }
+func (l *docsprawlLinter) Inputs(req lint.Request) []string   { return pathsOr(req.Paths, l.paths) }
+
+func (l *docsprawlLinter) BindFlags(fs *flag.FlagSet) {
+	fs.IntVar(&l.cfg.MaxReadmeLines, "max-readme", l.cfg.MaxReadmeLines, "maximum allowed README lines")
+	fs.IntVar(&l.cfg.MaxFilesPerDir, "max-files", l.cfg.MaxFilesPerDir, "maximum markdown files per directory")
+	fs.Float64Var(&l.cfg.DuplicateCutoff, "duplicate-cutoff", l.cfg.DuplicateCutoff, "similarity threshold for near-duplicates (0-1]")
+	//nolint:errcheck // CLI usage output
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: lintkit docsprawl [--max-readme=N] [--max-files=N] ROOT...\n")
+		fs.PrintDefaults()
+	}
+}
+
+func (l *docsprawlLinter) Run(_ context.Context, req lint.Request) ([]sarif.Result, error) {
+	roots := pathsOr(req.Paths, l.paths)
+	if len(roots) == 0 {
+		return nil, errors.New("at least one ROOT must be specified")
+	}
+	res, err := docsprawl.Run(roots, l.cfg)
+	if err != nil {
+		return nil, err
+	}
+	return onlyChanged(resultsOf(res.Log), req.Changed), nil
+}
+
+// onlyChanged narrows the per-file checks to changed documents: oversized
+// READMEs in a changed file and duplicate pairs with a changed member.
+// Orphan and directory checks depend on the whole tree and are kept.
+func onlyChanged(results []sarif.Result, changed func(string) bool) []sarif.Result {
+	if changed == nil {
+		return results
+	}
+	kept := results[:0]
+	for _, r := range results {
+		switch r.RuleID {
+		case "doc-readme-too-large", "doc-duplicate":
+			if !anyLocation(r, changed) {
+				continue
+			}
+		}
+		kept = append(kept, r)
+	}
+	return kept
+}
+
+func anyLocation(r sarif.Result, changed func(string) bool) bool {
+	for _, loc := range slices.Concat(r.Locations, r.RelatedLocations) {
+		if changed(loc.PhysicalLocation.ArtifactLocation.URI) {
+			return true
+		}
+	}
+	return false
+}
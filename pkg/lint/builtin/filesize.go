@@ -0,0 +1,94 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/filesize"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+type filesizeLinter struct {
+	project *config.Config
+	rules   string
+}
+
+func newFilesize(project *config.Config) *filesizeLinter {
+	return &filesizeLinter{project: project}
+}
+
+func (l *filesizeLinter) Name() string                       { return "filesize" }
+func (l *filesizeLinter) ToolName() string                   { return filesize.ToolName }
+func (l *filesizeLinter) Rules() []sarif.ReportingDescriptor { return filesize.Rules() }
+
+func (l *filesizeLinter) Inputs(req lint.Request) []string { return withFiles(l.paths(req), l.rules) }
+
+func (l *filesizeLinter) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&l.rules, "rules", "", "Path to YAML rules file (default: filesize section of the project config)")
+}
+
+func (l *filesizeLinter) Run(_ context.Context, req lint.Request) ([]sarif.Result, error) {
+	analyzer, paths, err := l.analyzer(req)
+	if err != nil {
+		return nil, err
+	}
+	log, err := analyzer.Analyze(paths)
+	if err != nil {
+		return nil, err
+	}
+	return resultsOf(log), nil
+}
+
+// Stream reports findings file by file; without rules that is a metrics
+// note for every file walked.
+func (l *filesizeLinter) Stream(_ context.Context, req lint.Request, emit func(sarif.Result) error) error {
+	analyzer, paths, err := l.analyzer(req)
+	if err != nil {
+		return err
+	}
+	return analyzer.Stream(paths, emit)
+}
+
+func (l *filesizeLinter) analyzer(req lint.Request) (*filesize.Analyzer, []string, error) {
+	sec := l.project.Filesize
+	if l.rules == "" && sec == nil {
+		return nil, nil, errors.New("--rules is required")
+	}
+
+	var rules []filesize.Rule
+	if l.rules != "" {
+		var err error
+		rules, err = filesize.LoadRules(l.rules)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		for i, r := range sec.Rules {
+			rule, err := filesize.ParseRule(r.Pattern, r.Max)
+			if err != nil {
+				return nil, nil, fmt.Errorf("filesize rule %d: %w", i, err)
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	paths := l.paths(req)
+
+	analyzer := filesize.NewAnalyzer(rules)
+	analyzer.Walk = l.project.WalkOptions()
+	analyzer.Walk.Filter = req.Changed
+	return analyzer, paths, nil
+}
+
+// paths returns the paths a request analyzes; none means the working
+// directory.
+func (l *filesizeLinter) paths(req lint.Request) []string {
+	if len(req.Paths) == 0 && l.project.Filesize != nil {
+		return l.project.PathsOr(l.project.Filesize.Paths)
+	}
+	return req.Paths
+}
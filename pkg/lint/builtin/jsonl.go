@@ -0,0 +1,75 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"flag"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/jsonl"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+type jsonlLinter struct {
+	project *config.Config
+	sec     *config.JSONL
+	schema  string
+}
+
+func newJSONL(project *config.Config) *jsonlLinter {
+	sec := project.JSONL
+	if sec == nil {
+		sec = &config.JSONL{}
+	}
+	return &jsonlLinter{project: project, sec: sec, schema: project.Resolve(sec.Schema)}
+}
+
+func (l *jsonlLinter) Name() string                       { return "jsonl" }
+func (l *jsonlLinter) ToolName() string                   { return jsonl.ToolName }
+func (l *jsonlLinter) Rules() []sarif.ReportingDescriptor { return jsonl.Rules() }
+func (l *jsonlLinter) MatchesInput(path string) bool {
+	return hasExt(path, ".jsonl") || isFile(path, l.schema)
+}
+
+func (l *jsonlLinter) Inputs(req lint.Request) []string {
+	return withFiles(l.files(req), l.schema)
+}
+
+func (l *jsonlLinter) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&l.schema, "schema", l.schema, "path to JSON Schema file")
+}
+
+func (l *jsonlLinter) Run(_ context.Context, req lint.Request) ([]sarif.Result, error) {
+	if l.schema == "" {
+		return nil, errors.New("--schema is required")
+	}
+
+	files := l.files(req)
+	if len(files) == 0 {
+		return nil, errors.New("at least one JSONL path is required")
+	}
+
+	validator, err := jsonl.NewValidator(l.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []sarif.Result
+	for _, path := range files {
+		if req.Changed != nil && !req.Changed(path) {
+			continue
+		}
+		found, err := jsonl.ValidateFile(path, validator)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, found...)
+	}
+	return results, nil
+}
+
+// files returns the JSONL files a request validates.
+func (l *jsonlLinter) files(req lint.Request) []string {
+	return pathsOr(req.Paths, l.project.ResolveAll(l.sec.Paths))
+}
@@ -0,0 +1,58 @@
+package builtin
+
+import (
+	"context"
+	"flag"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/mdsanity"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+type mdsanityLinter struct {
+	cfg mdsanity.Config
+}
+
+func newMdsanity(project *config.Config) *mdsanityLinter {
+	l := &mdsanityLinter{}
+	if project.Mdsanity != nil {
+		l.cfg = project.Mdsanity.Config
+	}
+	if l.cfg.RepoRoot == "" {
+		l.cfg.RepoRoot = "."
+	}
+	l.cfg.RepoRoot = project.Resolve(l.cfg.RepoRoot)
+	l.cfg.Walk = project.WalkOptions()
+	return l
+}
+
+func (l *mdsanityLinter) Name() string                       { return "mdsanity" }
+func (l *mdsanityLinter) ToolName() string                   { return mdsanity.ToolName }
+func (l *mdsanityLinter) Rules() []sarif.ReportingDescriptor { return mdsanity.Rules() }
+func (l *mdsanityLinter) MatchesInput(path string) bool      { return hasExt(path, ".md") }
+func (l *mdsanityLinter) Inputs(req lint.Request) []string   { return []string{l.root(req)} }
+
+func (l *mdsanityLinter) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&l.cfg.RepoRoot, "root", l.cfg.RepoRoot, "repository root to analyze")
+}
+
+// Run analyzes the configured repository root; a single path argument
+// overrides it.
+func (l *mdsanityLinter) Run(_ context.Context, req lint.Request) ([]sarif.Result, error) {
+	cfg := l.cfg
+	cfg.RepoRoot = l.root(req)
+	log, err := mdsanity.Run(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return sarif.Rebase(resultsOf(log), cfg.RepoRoot), nil
+}
+
+// root returns the repository root a request analyzes.
+func (l *mdsanityLinter) root(req lint.Request) string {
+	if len(req.Paths) > 0 {
+		return req.Paths[0]
+	}
+	return l.cfg.RepoRoot
+}
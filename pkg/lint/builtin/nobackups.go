@@ -0,0 +1,43 @@
+package builtin
+
+import (
+	"context"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/nobackups"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/walk"
+)
+
+type nobackupsLinter struct {
+	paths []string
+	walk  walk.Options
+}
+
+func newNobackups(project *config.Config) *nobackupsLinter {
+	l := &nobackupsLinter{walk: project.WalkOptions()}
+	if project.Nobackups != nil {
+		l.paths = project.PathsOr(project.Nobackups.Paths)
+	}
+	return l
+}
+
+func (l *nobackupsLinter) Name() string                       { return "nobackups" }
+func (l *nobackupsLinter) ToolName() string                   { return nobackups.ToolName }
+func (l *nobackupsLinter) Rules() []sarif.ReportingDescriptor { return nobackups.Rules() }
+func (l *nobackupsLinter) Inputs(req lint.Request) []string   { return pathsOr(req.Paths, l.paths) }
+
+func (l *nobackupsLinter) Run(_ context.Context, req lint.Request) ([]sarif.Result, error) {
+	paths := pathsOr(req.Paths, l.paths)
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	opts := l.walk
+	opts.Filter = req.Changed
+	log, err := nobackups.ScanWithOptions(paths, opts)
+	if err != nil {
+		return nil, err
+	}
+	return resultsOf(log), nil
+}
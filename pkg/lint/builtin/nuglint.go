@@ -0,0 +1,41 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/nuglint"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/walk"
+)
+
+type nuglintLinter struct {
+	paths []string
+	walk  walk.Options
+}
+
+func newNuglint(project *config.Config) *nuglintLinter {
+	l := &nuglintLinter{walk: project.WalkOptions()}
+	if project.Nuglint != nil {
+		l.paths = project.PathsOr(project.Nuglint.Paths)
+	}
+	return l
+}
+
+func (l *nuglintLinter) Name() string                       { return "nuglint" }
+func (l *nuglintLinter) ToolName() string                   { return nuglint.ToolName }
+func (l *nuglintLinter) Rules() []sarif.ReportingDescriptor { return nuglint.Rules() }
+func (l *nuglintLinter) MatchesInput(path string) bool      { return hasExt(path, ".jsonl") }
+func (l *nuglintLinter) Inputs(req lint.Request) []string   { return pathsOr(req.Paths, l.paths) }
+
+func (l *nuglintLinter) Run(_ context.Context, req lint.Request) ([]sarif.Result, error) {
+	paths := pathsOr(req.Paths, l.paths)
+	if len(paths) == 0 {
+		return nil, errors.New("nuglint requires at least one path")
+	}
+	opts := l.walk
+	opts.Filter = req.Changed
+	return nuglint.RunWithOptions(paths, opts)
+}
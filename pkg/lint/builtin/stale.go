@@ -0,0 +1,74 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/stale"
+)
+
+type staleLinter struct {
+	project *config.Config
+	rules   string
+}
+
+func newStale(project *config.Config) *staleLinter {
+	return &staleLinter{project: project}
+}
+
+func (l *staleLinter) Name() string                       { return "stale" }
+func (l *staleLinter) ToolName() string                   { return stale.ToolName }
+func (l *staleLinter) Rules() []sarif.ReportingDescriptor { return stale.Rules() }
+
+func (l *staleLinter) Inputs(req lint.Request) []string { return withFiles(l.paths(req), l.rules) }
+
+func (l *staleLinter) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&l.rules, "rules", "", "Path to the staleness rules file (default: stale section of the project config)")
+}
+
+func (l *staleLinter) Run(_ context.Context, req lint.Request) ([]sarif.Result, error) {
+	sec := l.project.Stale
+	if l.rules == "" && sec == nil {
+		return nil, errors.New("--rules is required")
+	}
+
+	paths := l.paths(req)
+
+	var cfg stale.Config
+	if l.rules != "" {
+		var err error
+		cfg, err = stale.LoadConfig(l.rules)
+		if err != nil {
+			return nil, fmt.Errorf("load config: %w", err)
+		}
+	} else {
+		cfg = sec.Config
+	}
+
+	var results []sarif.Result
+	for _, root := range paths {
+		found, err := stale.Evaluate(root, cfg)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, sarif.Rebase(found, root)...)
+	}
+	return results, nil
+}
+
+// paths returns the roots a request evaluates.
+func (l *staleLinter) paths(req lint.Request) []string {
+	paths := req.Paths
+	if len(paths) == 0 && l.project.Stale != nil {
+		paths = l.project.PathsOr(l.project.Stale.Paths)
+	}
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	return paths
+}
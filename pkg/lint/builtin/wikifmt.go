@@ -0,0 +1,45 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dkoosis/lintkit/pkg/config"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/walk"
+	"github.com/dkoosis/lintkit/pkg/wikifmt"
+)
+
+type wikifmtLinter struct {
+	paths []string
+	walk  walk.Options
+}
+
+func newWikifmt(project *config.Config) *wikifmtLinter {
+	l := &wikifmtLinter{walk: project.WalkOptions()}
+	if project.Wikifmt != nil {
+		l.paths = project.PathsOr(project.Wikifmt.Paths)
+	}
+	return l
+}
+
+func (l *wikifmtLinter) Name() string                       { return "wikifmt" }
+func (l *wikifmtLinter) ToolName() string                   { return wikifmt.ToolName }
+func (l *wikifmtLinter) Rules() []sarif.ReportingDescriptor { return wikifmt.Rules() }
+func (l *wikifmtLinter) MatchesInput(path string) bool      { return hasExt(path, ".md") }
+func (l *wikifmtLinter) Inputs(req lint.Request) []string   { return pathsOr(req.Paths, l.paths) }
+
+func (l *wikifmtLinter) Run(_ context.Context, req lint.Request) ([]sarif.Result, error) {
+	roots := pathsOr(req.Paths, l.paths)
+	if len(roots) == 0 {
+		return nil, errors.New("wikifmt requires at least one ROOT directory")
+	}
+	// Links are resolved against every page, so the whole tree is indexed
+	// and only findings in changed pages are kept.
+	log, err := wikifmt.RunWithOptions(roots, l.walk)
+	if err != nil {
+		return nil, err
+	}
+	return req.Only(resultsOf(log)), nil
+}
@@ -0,0 +1,62 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// FailOn is an exit-code policy: the lowest SARIF level of an active result
+// that makes a run fail.
+type FailOn string
+
+// Supported FailOn policies.
+const (
+	FailOnError   FailOn = "error"
+	FailOnWarning FailOn = "warning"
+	FailOnNote    FailOn = "note"
+	FailOnNone    FailOn = "none"
+)
+
+// DefaultFailOn is used when neither a flag nor the project config sets a
+// policy.
+const DefaultFailOn = FailOnError
+
+// ParseFailOn validates a policy name. The empty string selects
+// DefaultFailOn.
+func ParseFailOn(s string) (FailOn, error) {
+	switch f := FailOn(s); f {
+	case "":
+		return DefaultFailOn, nil
+	case FailOnError, FailOnWarning, FailOnNote, FailOnNone:
+		return f, nil
+	default:
+		return "", fmt.Errorf("invalid fail-on level %q (want error, warning, note, or none)", s)
+	}
+}
+
+// Count returns the number of active results in log whose level is at or
+// above the policy threshold. It is always zero for FailOnNone.
+func (f FailOn) Count(log *sarif.Log) int {
+	if log == nil {
+		return 0
+	}
+	n := 0
+	for _, run := range log.Runs {
+		for _, r := range run.Results {
+			if f.Fails(r) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Fails reports whether r is an active result at or above the policy
+// threshold. It is always false for FailOnNone.
+func (f FailOn) Fails(r sarif.Result) bool {
+	if f == FailOnNone {
+		return false
+	}
+	return r.IsActive() && sarif.LevelRank(r.Level) >= sarif.LevelRank(string(f))
+}
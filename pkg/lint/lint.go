@@ -0,0 +1,265 @@
+// Package lint defines the common interface implemented by lintkit linters
+// and a registry used to look them up by name.
+package lint
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// Request carries the inputs for a single linter run.
+type Request struct {
+	// Paths lists the files, directories, or databases to analyze. Linters
+	// fall back to their configured defaults when it is empty.
+	Paths []string
+	// Changed, when non-nil, restricts per-file checks to the files it
+	// accepts. Linters still read the whole tree where they need context,
+	// such as an index for resolving links.
+	Changed func(path string) bool
+	// Root, when set, is the source root artifact URIs are made relative
+	// to; see sarif.Run.Relativize.
+	Root string
+}
+
+// Only returns the results whose primary artifact changed according to
+// req, or all results when req has no change filter.
+func (req Request) Only(results []sarif.Result) []sarif.Result {
+	if req.Changed == nil {
+		return results
+	}
+	kept := results[:0]
+	for _, r := range results {
+		if uri := r.PrimaryURI(); uri != "" && req.Changed(uri) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// Linter is a single lintkit check.
+type Linter interface {
+	// Name is the short command name, e.g. "wikifmt".
+	Name() string
+	// Rules describes every rule the linter can report.
+	Rules() []sarif.ReportingDescriptor
+	// Run analyzes the request and returns its findings.
+	Run(ctx context.Context, req Request) ([]sarif.Result, error)
+}
+
+// FlagBinder is implemented by linters whose options can be set from
+// command-line flags. BindFlags is called before the flags are parsed.
+type FlagBinder interface {
+	BindFlags(fs *flag.FlagSet)
+}
+
+// ToolNamer is implemented by linters whose SARIF driver name differs from
+// the default "lintkit-<name>".
+type ToolNamer interface {
+	ToolName() string
+}
+
+// InputMatcher is implemented by linters that read only some kinds of
+// files. Watchers re-run such a linter only when a matching file changes;
+// linters without it are re-run on every change.
+type InputMatcher interface {
+	MatchesInput(path string) bool
+}
+
+// InputLister is implemented by linters that can name the files and
+// directories a request reads, including option files such as schemas, so
+// a watcher polls only those.
+type InputLister interface {
+	Inputs(req Request) []string
+}
+
+// Streamer is implemented by linters that can report findings one at a
+// time as they are found, so a large result set need not be held in
+// memory. Stream passes the findings Run would return to emit and stops at
+// the first error emit returns.
+type Streamer interface {
+	Stream(ctx context.Context, req Request, emit func(sarif.Result) error) error
+}
+
+// Affected reports whether a change to path can change l's results.
+func Affected(l Linter, path string) bool {
+	if m, ok := l.(InputMatcher); ok {
+		return m.MatchesInput(path)
+	}
+	return true
+}
+
+// Inputs returns the files and directories l reads for req: those it
+// lists, else the request's paths, else the working directory.
+func Inputs(l Linter, req Request) []string {
+	if il, ok := l.(InputLister); ok {
+		if paths := il.Inputs(req); len(paths) > 0 {
+			return paths
+		}
+	}
+	if len(req.Paths) > 0 {
+		return req.Paths
+	}
+	return []string{"."}
+}
+
+// Factory creates a fresh, independently configurable linter instance.
+type Factory func() Linter
+
+// ToolName returns the SARIF driver name for a linter.
+func ToolName(l Linter) string {
+	if n, ok := l.(ToolNamer); ok {
+		return n.ToolName()
+	}
+	return "lintkit-" + l.Name()
+}
+
+// Driver returns the SARIF driver describing a linter and its rules.
+func Driver(l Linter) sarif.Driver {
+	return sarif.Driver{Name: ToolName(l), Rules: l.Rules()}
+}
+
+// RunLog runs a linter and wraps its findings in a single-run SARIF log
+// whose invocation records when the linter ran and whether it succeeded.
+// When the linter fails, the log holds the failed invocation, with the
+// error as a notification, and is returned alongside the error.
+func RunLog(ctx context.Context, l Linter, req Request) (*sarif.Log, error) {
+	inv := startInvocation()
+	results, err := l.Run(ctx, req)
+	endInvocation(&inv, err)
+	if err != nil {
+		results = nil
+	}
+
+	run := sarif.NewRun(Driver(l), results)
+	run.Invocations = []sarif.Invocation{inv}
+	if req.Root != "" {
+		if relErr := run.Relativize(req.Root); relErr != nil && err == nil {
+			err = relErr
+		}
+	}
+	log := sarif.NewLog()
+	log.Runs = append(log.Runs, run)
+	return log, err
+}
+
+// StreamLog runs a linter like RunLog but writes its run to w as findings
+// are found, streaming them from linters that implement Streamer. keep,
+// when non-nil, sees each finding before it is written and may change it
+// or drop it by returning false. The run is left open: the caller
+// completes the returned invocation and passes it to w.EndRun. When the
+// linter fails, findings already written stay in the run and the
+// invocation records the failure.
+func StreamLog(ctx context.Context, l Linter, req Request, w *sarif.Writer, keep func(run *sarif.Run, r *sarif.Result) bool) (sarif.Invocation, error) {
+	inv := startInvocation()
+	run := sarif.Run{Tool: sarif.Tool{Driver: Driver(l)}}
+	if req.Root != "" {
+		if err := run.Relativize(req.Root); err != nil {
+			return inv, err
+		}
+	}
+	if err := w.BeginRun(run); err != nil {
+		return inv, err
+	}
+
+	emit := func(r sarif.Result) error {
+		if keep != nil && !keep(&run, &r) {
+			return nil
+		}
+		return w.WriteResult(r)
+	}
+	var err error
+	if s, ok := l.(Streamer); ok {
+		err = s.Stream(ctx, req, emit)
+	} else {
+		var results []sarif.Result
+		results, err = l.Run(ctx, req)
+		for i := 0; err == nil && i < len(results); i++ {
+			err = emit(results[i])
+		}
+	}
+	endInvocation(&inv, err)
+	return inv, err
+}
+
+// startInvocation returns an invocation started now in the working
+// directory.
+func startInvocation() sarif.Invocation {
+	inv := sarif.Invocation{StartTimeUTC: time.Now().UTC()}
+	if wd, err := os.Getwd(); err == nil {
+		inv.WorkingDirectory = &sarif.ArtifactLocation{URI: sarif.FileURI(wd)}
+	}
+	return inv
+}
+
+// endInvocation records that inv ended now, failing with err when it is
+// not nil.
+func endInvocation(inv *sarif.Invocation, err error) {
+	inv.EndTimeUTC = time.Now().UTC()
+	inv.ExecutionSuccessful = err == nil
+	if err != nil {
+		inv.ToolExecutionNotifications = []sarif.Notification{{Level: "error", Message: sarif.Message{Text: err.Error()}}}
+	}
+}
+
+// Registry maps linter names to factories.
+type Registry struct {
+	entries map[string]entry
+}
+
+type entry struct {
+	summary string
+	factory Factory
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{entries: map[string]entry{}}
+}
+
+// Register adds a linter factory under the name reported by its linters.
+// Registering the same name twice is an error.
+func (r *Registry) Register(summary string, factory Factory) error {
+	name := factory().Name()
+	if _, exists := r.entries[name]; exists {
+		return fmt.Errorf("linter %q already registered", name)
+	}
+	r.entries[name] = entry{summary: summary, factory: factory}
+	return nil
+}
+
+// MustRegister is like Register but panics on duplicate names.
+func (r *Registry) MustRegister(summary string, factory Factory) {
+	if err := r.Register(summary, factory); err != nil {
+		panic(err)
+	}
+}
+
+// New returns a fresh instance of the named linter.
+func (r *Registry) New(name string) (Linter, bool) {
+	e, ok := r.entries[name]
+	if !ok {
+		return nil, false
+	}
+	return e.factory(), true
+}
+
+// Summary returns the one-line description of the named linter.
+func (r *Registry) Summary(name string) string {
+	return r.entries[name].summary
+}
+
+// Names returns the registered linter names in lexical order.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.entries))
+	for name := range r.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,214 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+type fakeLinter struct {
+	name string
+}
+
+func (f fakeLinter) Name() string { return f.name }
+
+func (f fakeLinter) Rules() []sarif.ReportingDescriptor {
+	return []sarif.ReportingDescriptor{{ID: "fake-rule"}}
+}
+
+func (f fakeLinter) Run(context.Context, Request) ([]sarif.Result, error) {
+	return []sarif.Result{{RuleID: "fake-rule", Level: "warning", Message: sarif.Message{Text: "found"}}}, nil
+}
+
+func TestRegistry(t *testing.T) {
+	reg := NewRegistry()
+	reg.MustRegister("second", func() Linter { return fakeLinter{name: "zeta"} })
+	reg.MustRegister("first", func() Linter { return fakeLinter{name: "alpha"} })
+
+	if err := reg.Register("dup", func() Linter { return fakeLinter{name: "alpha"} }); err == nil {
+		t.Fatalf("expected duplicate registration to fail")
+	}
+
+	names := reg.Names()
+	if len(names) != 2 || names[0] != "alpha" || names[1] != "zeta" {
+		t.Fatalf("unexpected names: %v", names)
+	}
+	if got := reg.Summary("alpha"); got != "first" {
+		t.Fatalf("unexpected summary: %q", got)
+	}
+	if _, ok := reg.New("missing"); ok {
+		t.Fatalf("expected unknown linter lookup to fail")
+	}
+	l, ok := reg.New("zeta")
+	if !ok || l.Name() != "zeta" {
+		t.Fatalf("unexpected linter: %v %v", l, ok)
+	}
+}
+
+func TestRunLog(t *testing.T) {
+	log, err := RunLog(context.Background(), fakeLinter{name: "fake"}, Request{})
+	if err != nil {
+		t.Fatalf("RunLog: %v", err)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("expected one run, got %d", len(log.Runs))
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "lintkit-fake" {
+		t.Fatalf("unexpected driver name: %q", run.Tool.Driver.Name)
+	}
+	if len(run.Results) != 1 || run.Results[0].RuleIndex == nil || *run.Results[0].RuleIndex != 0 {
+		t.Fatalf("expected indexed result, got %+v", run.Results)
+	}
+}
+
+type listingLinter struct {
+	fakeLinter
+	inputs []string
+}
+
+func (l listingLinter) Inputs(Request) []string { return l.inputs }
+
+func TestInputs(t *testing.T) {
+	for _, tc := range []struct {
+		l    Linter
+		req  Request
+		want string
+	}{
+		{listingLinter{inputs: []string{"wiki", "schema.json"}}, Request{Paths: []string{"x"}}, "wiki schema.json"},
+		{listingLinter{}, Request{Paths: []string{"x"}}, "x"},
+		{fakeLinter{}, Request{}, "."},
+	} {
+		if got := strings.Join(Inputs(tc.l, tc.req), " "); got != tc.want {
+			t.Fatalf("Inputs(%T): got %q, want %q", tc.l, got, tc.want)
+		}
+	}
+}
+
+func TestRequestOnly(t *testing.T) {
+	at := func(uri string) sarif.Result {
+		return sarif.Result{RuleID: "r", Locations: []sarif.Location{{PhysicalLocation: sarif.PhysicalLocation{ArtifactLocation: sarif.ArtifactLocation{URI: uri}}}}}
+	}
+	results := []sarif.Result{at("a.md"), at("b.md"), {RuleID: "r"}}
+
+	if got := (Request{}).Only(results); len(got) != 3 {
+		t.Fatalf("expected all results without a change filter, got %d", len(got))
+	}
+	req := Request{Changed: func(path string) bool { return path == "b.md" }}
+	got := req.Only(results)
+	if len(got) != 1 || got[0].PrimaryURI() != "b.md" {
+		t.Fatalf("expected only b.md, got %+v", got)
+	}
+}
+
+func TestFailOnCount(t *testing.T) {
+	log := sarif.NewLog()
+	log.Runs = append(log.Runs, sarif.Run{Results: []sarif.Result{
+		{RuleID: "a", Level: "error"},
+		{RuleID: "b", Level: "warning"},
+		{RuleID: "c"},
+		{RuleID: "d", Level: "note"},
+		{RuleID: "e", Level: "error", Suppressions: []sarif.Suppression{{Kind: "external"}}},
+	}})
+
+	cases := map[string]int{"": 1, "error": 1, "warning": 3, "note": 4, "none": 0}
+	for name, want := range cases {
+		f, err := ParseFailOn(name)
+		if err != nil {
+			t.Fatalf("ParseFailOn(%q): %v", name, err)
+		}
+		if got := f.Count(log); got != want {
+			t.Fatalf("fail-on %q: got %d, want %d", name, got, want)
+		}
+	}
+
+	if _, err := ParseFailOn("fatal"); err == nil {
+		t.Fatalf("expected invalid level to be rejected")
+	}
+}
+
+type failingLinter struct{ fakeLinter }
+
+func (failingLinter) Run(context.Context, Request) ([]sarif.Result, error) {
+	return nil, errors.New("boom")
+}
+
+func TestRunLogRecordsInvocation(t *testing.T) {
+	log, err := RunLog(context.Background(), fakeLinter{name: "fake"}, Request{Root: "."})
+	if err != nil {
+		t.Fatalf("RunLog: %v", err)
+	}
+	run := log.Runs[0]
+	if len(run.Invocations) != 1 {
+		t.Fatalf("expected one invocation, got %+v", run.Invocations)
+	}
+	inv := run.Invocations[0]
+	if !inv.ExecutionSuccessful || inv.StartTimeUTC.IsZero() || inv.EndTimeUTC.Before(inv.StartTimeUTC) {
+		t.Fatalf("unexpected invocation: %+v", inv)
+	}
+	if inv.WorkingDirectory == nil || inv.WorkingDirectory.URI == "" {
+		t.Fatalf("expected working directory, got %+v", inv)
+	}
+	if _, ok := run.OriginalURIBaseIDs[sarif.SrcRoot]; !ok {
+		t.Fatalf("expected %s base, got %+v", sarif.SrcRoot, run.OriginalURIBaseIDs)
+	}
+
+	log, err = RunLog(context.Background(), failingLinter{fakeLinter{name: "fail"}}, Request{})
+	if err == nil || log == nil {
+		t.Fatalf("expected failed log and error, got %v %v", log, err)
+	}
+	inv = log.Runs[0].Invocations[0]
+	if inv.ExecutionSuccessful || len(inv.ToolExecutionNotifications) != 1 || inv.ToolExecutionNotifications[0].Message.Text != "boom" {
+		t.Fatalf("expected failure notification, got %+v", inv)
+	}
+}
+
+type streamingLinter struct{ fakeLinter }
+
+func (streamingLinter) Run(context.Context, Request) ([]sarif.Result, error) {
+	return nil, errors.New("Run called on a streamer")
+}
+
+func (streamingLinter) Stream(_ context.Context, _ Request, emit func(sarif.Result) error) error {
+	for _, text := range []string{"keep", "drop"} {
+		if err := emit(sarif.Result{RuleID: "fake-rule", Message: sarif.Message{Text: text}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestStreamLog(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := sarif.NewWriter(buf)
+	keep := func(_ *sarif.Run, r *sarif.Result) bool { return r.Message.Text == "keep" }
+	inv, err := StreamLog(context.Background(), streamingLinter{fakeLinter{name: "stream"}}, Request{}, w, keep)
+	if err != nil {
+		t.Fatalf("StreamLog: %v", err)
+	}
+	if !inv.ExecutionSuccessful || inv.StartTimeUTC.IsZero() {
+		t.Fatalf("unexpected invocation: %+v", inv)
+	}
+	if err := w.EndRun(inv); err != nil {
+		t.Fatalf("EndRun: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	log, err := sarif.Decode(buf)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "lintkit-stream" || len(run.Invocations) != 1 {
+		t.Fatalf("unexpected run: %+v", run)
+	}
+	if len(run.Results) != 1 || run.Results[0].Message.Text != "keep" || run.Results[0].RuleIndex == nil {
+		t.Fatalf("expected the kept, indexed result, got %+v", run.Results)
+	}
+}
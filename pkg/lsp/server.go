@@ -0,0 +1,389 @@
+// Package lsp serves lintkit diagnostics to editors over the Language
+// Server Protocol. The server keeps a wikifmt index of the wiki in memory
+// and re-checks markdown pages and nugget JSONL files as they are edited,
+// publishing SARIF results as LSP diagnostics.
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/dkoosis/lintkit/pkg/nuglint"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/suppress"
+	"github.com/dkoosis/lintkit/pkg/walk"
+	"github.com/dkoosis/lintkit/pkg/wikifmt"
+)
+
+// DefaultNuggetDir is where nugget files live, relative to the workspace
+// root, when Options.NuggetRoots is empty.
+const DefaultNuggetDir = ".orca/kg"
+
+// Options configure a Server.
+type Options struct {
+	// WikiRoots are the directories indexed by wikifmt. Relative roots are
+	// resolved against the workspace root; empty means the workspace root.
+	WikiRoots []string
+	// NuggetRoots are the directories whose .jsonl files are checked by
+	// nuglint; empty means DefaultNuggetDir.
+	NuggetRoots []string
+	// Walk controls how the wiki roots are traversed.
+	Walk walk.Options
+	// Rules overrides rule levels or disables rules, as on the command line.
+	Rules sarif.Overrides
+}
+
+// Server is a stdio language server. It handles one client connection.
+type Server struct {
+	opts Options
+	conn *Conn
+
+	root    string
+	wiki    *wikifmt.Index
+	nuggets []string
+	// docs holds the text of open documents by absolute path.
+	docs     map[string]string
+	shutdown bool
+}
+
+// NewServer returns a server configured with opts.
+func NewServer(opts Options) *Server {
+	return &Server{opts: opts, docs: map[string]string{}}
+}
+
+// errExit is returned by handle when the client asks the server to exit.
+var errExit = errors.New("exit")
+
+// Serve reads requests from r and writes responses and notifications to w
+// until the client sends exit or closes r. Exiting without a prior
+// shutdown request is reported as an error.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.conn = NewConn(r, w)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		msg, err := s.conn.read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		var rpcErr *rpcError
+		if errors.As(err, &rpcErr) {
+			if err := s.conn.replyError(json.RawMessage("null"), rpcErr.Code, rpcErr.Message); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		err = s.handle(msg)
+		if errors.Is(err, errExit) {
+			if !s.shutdown {
+				return errors.New("exit before shutdown")
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// handle dispatches one message. Errors in a request's params are
+// answered on the wire; returned errors end the session.
+func (s *Server) handle(msg *message) error {
+	isRequest := len(msg.ID) > 0
+	var result any
+	var err error
+
+	switch msg.Method {
+	case "initialize":
+		var params InitializeParams
+		if err = unmarshal(msg.Params, &params); err == nil {
+			result, err = s.initialize(params)
+		}
+	case "initialized", "textDocument/didSave", "$/cancelRequest", "$/setTrace":
+	case "shutdown":
+		s.shutdown = true
+		result = nil
+	case "exit":
+		return errExit
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err = unmarshal(msg.Params, &params); err == nil {
+			err = s.open(params.TextDocument.URI, params.TextDocument.Text)
+		}
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err = unmarshal(msg.Params, &params); err == nil && len(params.ContentChanges) > 0 {
+			// Full synchronization: the last change holds the whole text.
+			text := params.ContentChanges[len(params.ContentChanges)-1].Text
+			err = s.open(params.TextDocument.URI, text)
+		}
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err = unmarshal(msg.Params, &params); err == nil {
+			err = s.close(params.TextDocument.URI)
+		}
+	default:
+		if isRequest {
+			return s.conn.replyError(msg.ID, codeMethodNotFound, "method not found: "+msg.Method)
+		}
+		return nil
+	}
+
+	if !isRequest {
+		var rpcErr *rpcError
+		if errors.As(err, &rpcErr) {
+			return nil // notifications get no reply
+		}
+		return err
+	}
+	if err != nil {
+		var rpcErr *rpcError
+		if errors.As(err, &rpcErr) {
+			return s.conn.replyError(msg.ID, rpcErr.Code, rpcErr.Message)
+		}
+		return err
+	}
+	return s.conn.reply(msg.ID, result)
+}
+
+func unmarshal(raw json.RawMessage, v any) error {
+	if err := json.Unmarshal(raw, v); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+// initialize resolves the workspace root and builds the wiki index.
+func (s *Server) initialize(params InitializeParams) (InitializeResult, error) {
+	root := params.RootPath
+	if len(params.WorkspaceFolders) > 0 {
+		root = uriToPath(params.WorkspaceFolders[0].URI)
+	}
+	if params.RootURI != "" {
+		root = uriToPath(params.RootURI)
+	}
+	if root == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return InitializeResult{}, err
+		}
+		root = wd
+	}
+	s.root = root
+
+	wikiRoots := s.resolve(s.opts.WikiRoots, ".")
+	s.nuggets = s.resolve(s.opts.NuggetRoots, DefaultNuggetDir)
+
+	wiki, err := wikifmt.NewIndex(wikiRoots, s.opts.Walk)
+	if err != nil {
+		return InitializeResult{}, fmt.Errorf("index wiki: %w", err)
+	}
+	s.wiki = wiki
+
+	return InitializeResult{
+		Capabilities: ServerCapabilities{TextDocumentSync: TextDocumentSyncOptions{OpenClose: true, Change: 1}},
+		ServerInfo:   ServerInfo{Name: "lintkit"},
+	}, nil
+}
+
+// resolve makes dirs absolute against the workspace root, defaulting to
+// fallback when dirs is empty.
+func (s *Server) resolve(dirs []string, fallback string) []string {
+	if len(dirs) == 0 {
+		dirs = []string{fallback}
+	}
+	out := make([]string, 0, len(dirs))
+	for _, d := range dirs {
+		if !filepath.IsAbs(d) {
+			d = filepath.Join(s.root, d)
+		}
+		out = append(out, filepath.Clean(d))
+	}
+	return out
+}
+
+// open records the text of a document and republishes diagnostics. An
+// edited wiki page can fix or break links elsewhere, so every open page is
+// re-checked.
+func (s *Server) open(uri, text string) error {
+	if s.wiki == nil {
+		return nil // not initialized
+	}
+	path := uriToPath(uri)
+	s.docs[path] = text
+	if s.wiki.Covers(path) {
+		s.wiki.Update(path, text)
+		return s.publishWiki()
+	}
+	return s.publish(path)
+}
+
+// close forgets an open document, reverting the index to its saved content,
+// and clears its diagnostics.
+func (s *Server) close(uri string) error {
+	if s.wiki == nil {
+		return nil
+	}
+	path := uriToPath(uri)
+	delete(s.docs, path)
+	if err := s.conn.Notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: pathToURI(path), Diagnostics: []Diagnostic{}}); err != nil {
+		return err
+	}
+	if s.wiki.Covers(path) {
+		if err := s.wiki.Reload(path); err != nil {
+			return err
+		}
+		return s.publishWiki()
+	}
+	return nil
+}
+
+// publishWiki republishes diagnostics for every open wiki page.
+func (s *Server) publishWiki() error {
+	paths := make([]string, 0, len(s.docs))
+	for path := range s.docs {
+		if s.wiki.Covers(path) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if err := s.publish(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// publish checks one open document and sends its diagnostics.
+func (s *Server) publish(path string) error {
+	text := s.docs[path]
+	var results []sarif.Result
+	var tool string
+	switch {
+	case s.wiki.Covers(path):
+		results, tool = s.wiki.Check(path), wikifmt.ToolName
+	case s.isNuggetFile(path):
+		found, err := nuglint.Lint(path, strings.NewReader(text))
+		if err != nil {
+			return err
+		}
+		results, tool = found, nuglint.ToolName
+	default:
+		return nil
+	}
+
+	lines := strings.Split(text, "\n")
+	directives := suppress.Parse(path, lines)
+	diagnostics := []Diagnostic{}
+	for _, r := range s.opts.Rules.Results(results) {
+		if r.PrimaryURI() != path || suppressed(r, directives) {
+			continue
+		}
+		diagnostics = append(diagnostics, ToDiagnostic(r, tool, lines))
+	}
+	return s.conn.Notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: pathToURI(path), Diagnostics: diagnostics})
+}
+
+func (s *Server) isNuggetFile(path string) bool {
+	if filepath.Ext(path) != ".jsonl" {
+		return false
+	}
+	for _, root := range s.nuggets {
+		rel, err := filepath.Rel(root, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func suppressed(r sarif.Result, directives []suppress.Directive) bool {
+	line := 0
+	if region := r.Locations[0].PhysicalLocation.Region; region != nil {
+		line = region.StartLine
+	}
+	for _, d := range directives {
+		if d.Matches(r.RuleID, line) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToDiagnostic maps a SARIF result to an LSP diagnostic. SARIF lines and
+// columns are one-based and LSP positions zero-based; both count columns in
+// UTF-16 code units. A region without columns spans its whole lines, using
+// lines (the document text split on "\n") to find their length.
+func ToDiagnostic(r sarif.Result, tool string, lines []string) Diagnostic {
+	d := Diagnostic{
+		Severity: Severity(r.Level),
+		Code:     r.RuleID,
+		Source:   tool,
+		Message:  r.Message.Formatted(),
+	}
+	if len(r.Locations) == 0 {
+		return d
+	}
+	region := r.Locations[0].PhysicalLocation.Region
+	if region == nil || region.StartLine == 0 {
+		return d
+	}
+
+	start := Position{Line: region.StartLine - 1}
+	if region.StartColumn > 0 {
+		start.Character = region.StartColumn - 1
+	}
+	end := Position{Line: start.Line}
+	if region.EndLine > 0 {
+		end.Line = region.EndLine - 1
+	}
+	if region.EndColumn > 0 {
+		end.Character = region.EndColumn - 1
+	} else if end.Line < len(lines) {
+		end.Character = len(utf16.Encode([]rune(strings.TrimSuffix(lines[end.Line], "\r"))))
+	}
+	d.Range = Range{Start: start, End: end}
+	return d
+}
+
+// Severity maps a SARIF level to an LSP diagnostic severity. An empty level
+// is SARIF's default, warning.
+func Severity(level string) int {
+	switch level {
+	case "error":
+		return SeverityError
+	case "warning", "":
+		return SeverityWarning
+	case "note":
+		return SeverityInformation
+	default:
+		return SeverityHint
+	}
+}
+
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return filepath.Clean(uri)
+	}
+	return filepath.Clean(filepath.FromSlash(u.Path))
+}
+
+func pathToURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
@@ -0,0 +1,181 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// client drives a Server over in-memory pipes.
+type client struct {
+	t    *testing.T
+	conn *Conn
+	done chan error
+}
+
+func startServer(t *testing.T, opts Options) *client {
+	t.Helper()
+	clientToServer, serverIn := io.Pipe()
+	serverOut, serverToClient := io.Pipe()
+	c := &client{t: t, conn: NewConn(serverOut, serverIn), done: make(chan error, 1)}
+	go func() {
+		err := NewServer(opts).Serve(context.Background(), clientToServer, serverToClient)
+		_ = serverToClient.Close()
+		c.done <- err
+	}()
+	return c
+}
+
+// call sends a request and returns its result, skipping notifications.
+func (c *client) call(id int, method string, params any) json.RawMessage {
+	c.t.Helper()
+	if err := c.conn.Call(id, method, params); err != nil {
+		c.t.Fatalf("call %s: %v", method, err)
+	}
+	for {
+		m, payload, err := c.conn.Receive()
+		if err != nil {
+			c.t.Fatalf("receive %s response: %v", method, err)
+		}
+		if m == "" {
+			return payload
+		}
+	}
+}
+
+func (c *client) notify(method string, params any) {
+	c.t.Helper()
+	if err := c.conn.Notify(method, params); err != nil {
+		c.t.Fatalf("notify %s: %v", method, err)
+	}
+}
+
+// diagnostics waits for the next publishDiagnostics notification for uri.
+func (c *client) diagnostics(uri string) []Diagnostic {
+	c.t.Helper()
+	for {
+		m, payload, err := c.conn.Receive()
+		if err != nil {
+			c.t.Fatalf("receive diagnostics: %v", err)
+		}
+		if m != "textDocument/publishDiagnostics" {
+			continue
+		}
+		var params PublishDiagnosticsParams
+		if err := json.Unmarshal(payload, &params); err != nil {
+			c.t.Fatalf("decode diagnostics: %v", err)
+		}
+		if params.URI == uri {
+			return params.Diagnostics
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func hasCode(diags []Diagnostic, code string) bool {
+	for _, d := range diags {
+		if d.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func TestServerPublishesWikiDiagnostics(t *testing.T) {
+	root := t.TempDir()
+	page := "---\ntitle: Home\ndate: 2024-01-01\ntags: [wiki, wiki]\n---\n"
+	writeFile(t, filepath.Join(root, "wiki", "home.md"), page+"See [[other]].\n")
+	writeFile(t, filepath.Join(root, "wiki", "other.md"), page)
+
+	c := startServer(t, Options{WikiRoots: []string{"wiki"}})
+	c.call(1, "initialize", InitializeParams{RootURI: pathToURI(root)})
+	c.notify("initialized", struct{}{})
+
+	uri := pathToURI(filepath.Join(root, "wiki", "home.md"))
+	c.notify("textDocument/didOpen", DidOpenTextDocumentParams{TextDocument: TextDocumentItem{URI: uri, LanguageID: "markdown", Version: 1, Text: page + "See [[missing]].\n"}})
+	diags := c.diagnostics(uri)
+	if !hasCode(diags, "wiki-link-broken") {
+		t.Fatalf("expected broken link diagnostic, got %+v", diags)
+	}
+	for _, d := range diags {
+		if d.Code == "wiki-link-broken" {
+			if d.Severity != SeverityError || d.Range.Start.Line != 5 || d.Range.End.Character != len("See [[missing]].") || d.Source != "lintkit-wikifmt" {
+				t.Fatalf("unexpected diagnostic mapping: %+v", d)
+			}
+		}
+	}
+
+	c.notify("textDocument/didChange", DidChangeTextDocumentParams{
+		TextDocument:   VersionedTextDocumentIdentifier{URI: uri, Version: 2},
+		ContentChanges: []TextDocumentContentChangeEvent{{Text: page + "See [[other]].\n"}},
+	})
+	if diags := c.diagnostics(uri); hasCode(diags, "wiki-link-broken") {
+		t.Fatalf("expected link to resolve after edit, got %+v", diags)
+	}
+
+	c.call(2, "shutdown", nil)
+	c.notify("exit", nil)
+	if err := <-c.done; err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+}
+
+func TestServerChecksNuggets(t *testing.T) {
+	root := t.TempDir()
+	c := startServer(t, Options{})
+	c.call(1, "initialize", InitializeParams{RootURI: pathToURI(root)})
+
+	uri := pathToURI(filepath.Join(root, ".orca", "kg", "nugs.jsonl"))
+	c.notify("textDocument/didOpen", DidOpenTextDocumentParams{TextDocument: TextDocumentItem{URI: uri, Text: "{not json\n"}})
+	if diags := c.diagnostics(uri); !hasCode(diags, "nug-json-parse") {
+		t.Fatalf("expected parse diagnostic, got %+v", diags)
+	}
+
+	c.notify("textDocument/didClose", DidCloseTextDocumentParams{TextDocument: TextDocumentIdentifier{URI: uri}})
+	if diags := c.diagnostics(uri); len(diags) != 0 {
+		t.Fatalf("expected diagnostics cleared on close, got %+v", diags)
+	}
+
+	if err := c.conn.Call(2, "textDocument/hover", struct{}{}); err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	if _, _, err := c.conn.Receive(); err == nil {
+		t.Fatalf("expected method not found error")
+	}
+
+	c.notify("exit", nil)
+	if err := <-c.done; err == nil {
+		t.Fatalf("expected exit without shutdown to be an error")
+	}
+}
+
+func TestToDiagnostic(t *testing.T) {
+	r := sarif.Result{
+		RuleID:  "r",
+		Level:   "note",
+		Message: sarif.Message{Text: "m"},
+		Locations: []sarif.Location{{PhysicalLocation: sarif.PhysicalLocation{
+			ArtifactLocation: sarif.ArtifactLocation{URI: "a.md"},
+			Region:           &sarif.Region{StartLine: 2, StartColumn: 3, EndColumn: 5},
+		}}},
+	}
+	d := ToDiagnostic(r, "tool", []string{"x", "héllo"})
+	want := Range{Start: Position{Line: 1, Character: 2}, End: Position{Line: 1, Character: 4}}
+	if d.Range != want || d.Severity != SeverityInformation || d.Source != "tool" {
+		t.Fatalf("unexpected diagnostic %+v", d)
+	}
+}
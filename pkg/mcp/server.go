@@ -0,0 +1,356 @@
+// Package mcp exposes lintkit linters as Model Context Protocol tools over
+// stdio, so agents can check the files they write before committing them.
+//
+// Each registered linter becomes a tool taking paths and the linter's
+// command-line options; a list_rules tool describes the rule catalogs.
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/dkoosis/lintkit/pkg/catalog"
+	"github.com/dkoosis/lintkit/pkg/format"
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// ProtocolVersion is the MCP revision the server implements.
+const ProtocolVersion = "2024-11-05"
+
+// ListRulesTool is the name of the tool describing rule catalogs.
+const ListRulesTool = "list_rules"
+
+// JSON-RPC error codes used by the server.
+const (
+	codeParseError     = -32700
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Options configure a Server.
+type Options struct {
+	// Version is reported as the server version.
+	Version string
+	// Postprocess, when non-nil, is applied to every log before it is
+	// returned, for example to drop excluded files and apply suppressions.
+	Postprocess func(*sarif.Log)
+	// Root, when set, is the source root artifact URIs are made relative
+	// to.
+	Root string
+}
+
+// Server answers MCP requests for the linters in a registry.
+type Server struct {
+	reg  *lint.Registry
+	opts Options
+
+	mu  sync.Mutex
+	out *json.Encoder
+}
+
+// NewServer returns a server exposing the linters in reg.
+func NewServer(reg *lint.Registry, opts Options) *Server {
+	return &Server{reg: reg, opts: opts}
+}
+
+// message is a JSON-RPC 2.0 request, notification, or response.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+// Serve reads newline-delimited JSON-RPC messages from r and writes
+// responses to w until r is closed.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.out = json.NewEncoder(w)
+	dec := json.NewDecoder(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var msg message
+		err := dec.Decode(&msg)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			// The stream cannot be resynchronized after a syntax error.
+			_ = s.write(&message{ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.handle(ctx, &msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) write(msg *message) error {
+	msg.JSONRPC = "2.0"
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.out.Encode(msg)
+}
+
+// handle answers one message. Notifications never get a reply.
+func (s *Server) handle(ctx context.Context, msg *message) error {
+	if len(msg.ID) == 0 {
+		return nil // notifications/initialized, notifications/cancelled, ...
+	}
+
+	var result any
+	var err error
+	switch msg.Method {
+	case "initialize":
+		result = s.initialize(msg.Params)
+	case "ping":
+		result = struct{}{}
+	case "tools/list":
+		result = map[string]any{"tools": s.tools()}
+	case "tools/call":
+		var params callParams
+		if err = json.Unmarshal(msg.Params, &params); err != nil {
+			err = &rpcError{Code: codeInvalidParams, Message: err.Error()}
+			break
+		}
+		result, err = s.call(ctx, params)
+	default:
+		err = &rpcError{Code: codeMethodNotFound, Message: "method not found: " + msg.Method}
+	}
+
+	if err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			return err
+		}
+		return s.write(&message{ID: msg.ID, Error: rpcErr})
+	}
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return s.write(&message{ID: msg.ID, Result: raw})
+}
+
+func (s *Server) initialize(params json.RawMessage) map[string]any {
+	// Answer with the client's revision when it asks for one; the tool
+	// surface used here is the same across revisions.
+	version := ProtocolVersion
+	var req struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if json.Unmarshal(params, &req) == nil && req.ProtocolVersion != "" {
+		version = req.ProtocolVersion
+	}
+	return map[string]any{
+		"protocolVersion": version,
+		"capabilities":    map[string]any{"tools": map[string]any{}},
+		"serverInfo":      map[string]any{"name": "lintkit", "version": s.opts.Version},
+	}
+}
+
+// tool describes one MCP tool.
+type tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+}
+
+// tools lists one tool per linter, in name order, then list_rules.
+func (s *Server) tools() []tool {
+	var out []tool
+	for _, name := range s.reg.Names() {
+		l, ok := s.reg.New(name)
+		if !ok {
+			continue
+		}
+		options := map[string]any{}
+		flags(l).VisitAll(func(f *flag.Flag) {
+			options[f.Name] = map[string]any{"type": "string", "description": f.Usage, "default": f.DefValue}
+		})
+		out = append(out, tool{
+			Name:        name,
+			Description: s.reg.Summary(name) + ". Returns a summary of findings, or the SARIF log when output is \"sarif\".",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"paths": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string"},
+						"description": "Files or directories to check; empty uses the project configuration",
+					},
+					"options": map[string]any{
+						"type":                 "object",
+						"properties":           options,
+						"additionalProperties": false,
+						"description":          "Command-line options of lintkit " + name,
+					},
+					"output": map[string]any{"type": "string", "enum": []string{"summary", "sarif"}, "default": "summary"},
+				},
+			},
+		})
+	}
+	return append(out, tool{
+		Name:        ListRulesTool,
+		Description: "List the rules each linter reports, with their default level and description.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"linter": map[string]any{"type": "string", "description": "Only list this linter's rules"},
+			},
+		},
+	})
+}
+
+// flags returns the flag set a linter binds, which is empty for linters
+// without options.
+func flags(l lint.Linter) *flag.FlagSet {
+	fs := flag.NewFlagSet(l.Name(), flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	if b, ok := l.(lint.FlagBinder); ok {
+		b.BindFlags(fs)
+	}
+	return fs
+}
+
+type callParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+type lintArguments struct {
+	Paths   []string       `json:"paths"`
+	Options map[string]any `json:"options"`
+	Output  string         `json:"output"`
+}
+
+// content is an MCP text content block.
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type callResult struct {
+	Content []content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+func textResult(text string, isError bool) callResult {
+	return callResult{Content: []content{{Type: "text", Text: text}}, IsError: isError}
+}
+
+// call runs a tool. Tool failures are reported in the result, as MCP
+// expects, rather than as protocol errors.
+func (s *Server) call(ctx context.Context, params callParams) (callResult, error) {
+	if params.Name == ListRulesTool {
+		var args struct {
+			Linter string `json:"linter"`
+		}
+		if len(params.Arguments) > 0 {
+			if err := json.Unmarshal(params.Arguments, &args); err != nil {
+				return callResult{}, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+			}
+		}
+		return s.listRules(args.Linter)
+	}
+
+	l, ok := s.reg.New(params.Name)
+	if !ok {
+		return callResult{}, &rpcError{Code: codeInvalidParams, Message: "unknown tool: " + params.Name}
+	}
+	var args lintArguments
+	if len(params.Arguments) > 0 {
+		if err := json.Unmarshal(params.Arguments, &args); err != nil {
+			return callResult{}, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+	}
+	if args.Output != "" && args.Output != "summary" && args.Output != "sarif" {
+		return textResult(fmt.Sprintf("unknown output %q: want summary or sarif", args.Output), true), nil
+	}
+
+	fs := flags(l)
+	names := make([]string, 0, len(args.Options))
+	for name := range args.Options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := fs.Set(name, fmt.Sprint(args.Options[name])); err != nil {
+			return textResult(fmt.Sprintf("option %s: %v", name, err), true), nil
+		}
+	}
+
+	log, err := lint.RunLog(ctx, l, lint.Request{Paths: args.Paths, Root: s.opts.Root})
+	if err != nil {
+		return textResult(err.Error(), true), nil
+	}
+	if s.opts.Postprocess != nil {
+		s.opts.Postprocess(log)
+	}
+
+	var buf bytes.Buffer
+	if args.Output == "sarif" {
+		if err := sarif.NewEncoder(&buf).Encode(log); err != nil {
+			return callResult{}, err
+		}
+		return textResult(buf.String(), false), nil
+	}
+	return textResult(summarize(log), false), nil
+}
+
+// summarize renders the active findings as text, one per line, after a
+// count.
+func summarize(log *sarif.Log) string {
+	var buf bytes.Buffer
+	n := lint.FailOnNote.Count(log)
+	if n == 0 {
+		return "no findings"
+	}
+	fmt.Fprintf(&buf, "%d finding(s):\n", n)
+	_ = format.Write(&buf, "text", log) // writes to memory
+	return buf.String()
+}
+
+func (s *Server) listRules(only string) (callResult, error) {
+	if only != "" {
+		if _, ok := s.reg.New(only); !ok {
+			return textResult("unknown linter: "+only, true), nil
+		}
+	}
+
+	var entries []catalog.Entry
+	for _, e := range catalog.Build(s.reg) {
+		if only == "" || e.Linter == only {
+			entries = append(entries, e)
+		}
+	}
+	var buf bytes.Buffer
+	if err := catalog.WriteTable(&buf, entries); err != nil {
+		return callResult{}, err
+	}
+	return textResult(buf.String(), false), nil
+}
@@ -0,0 +1,156 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/dkoosis/lintkit/pkg/lint"
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// echoLinter reports one finding per path, at the level given by --level.
+type echoLinter struct {
+	level string
+}
+
+func (l *echoLinter) Name() string { return "echo" }
+
+func (l *echoLinter) Rules() []sarif.ReportingDescriptor {
+	return []sarif.ReportingDescriptor{{ID: "echo-path", ShortDescription: sarif.Text("Echoes each path"), DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"}}}
+}
+
+func (l *echoLinter) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&l.level, "level", "warning", "level of each finding")
+}
+
+func (l *echoLinter) Run(_ context.Context, req lint.Request) ([]sarif.Result, error) {
+	var results []sarif.Result
+	for _, p := range req.Paths {
+		results = append(results, sarif.Result{
+			RuleID:    "echo-path",
+			Level:     l.level,
+			Message:   sarif.Message{Text: "saw " + p},
+			Locations: []sarif.Location{{PhysicalLocation: sarif.PhysicalLocation{ArtifactLocation: sarif.ArtifactLocation{URI: p}}}},
+		})
+	}
+	return results, nil
+}
+
+type session struct {
+	t   *testing.T
+	enc *json.Encoder
+	out *bufio.Scanner
+}
+
+func start(t *testing.T) *session {
+	t.Helper()
+	reg := lint.NewRegistry()
+	reg.MustRegister("Echo paths", func() lint.Linter { return &echoLinter{} })
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	go func() {
+		_ = NewServer(reg, Options{Version: "test"}).Serve(context.Background(), inR, outW)
+		_ = outW.Close()
+	}()
+	t.Cleanup(func() { _ = inW.Close() })
+	return &session{t: t, enc: json.NewEncoder(inW), out: bufio.NewScanner(outR)}
+}
+
+// call sends a request and decodes its result into v.
+func (s *session) call(id int, method string, params, v any) *rpcError {
+	s.t.Helper()
+	req := map[string]any{"jsonrpc": "2.0", "id": id, "method": method, "params": params}
+	if err := s.enc.Encode(req); err != nil {
+		s.t.Fatalf("send %s: %v", method, err)
+	}
+	if !s.out.Scan() {
+		s.t.Fatalf("no response to %s: %v", method, s.out.Err())
+	}
+	var msg message
+	if err := json.Unmarshal(s.out.Bytes(), &msg); err != nil {
+		s.t.Fatalf("decode response: %v", err)
+	}
+	if string(msg.ID) != strings.TrimSpace(string(mustJSON(s.t, id))) {
+		s.t.Fatalf("response id %s, want %d", msg.ID, id)
+	}
+	if msg.Error != nil {
+		return msg.Error
+	}
+	if err := json.Unmarshal(msg.Result, v); err != nil {
+		s.t.Fatalf("decode result: %v", err)
+	}
+	return nil
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return data
+}
+
+func TestServerExposesLintersAsTools(t *testing.T) {
+	s := start(t)
+
+	var init map[string]any
+	if err := s.call(1, "initialize", map[string]any{"protocolVersion": ProtocolVersion}, &init); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	if init["protocolVersion"] != ProtocolVersion {
+		t.Fatalf("unexpected initialize result %v", init)
+	}
+	if err := s.enc.Encode(map[string]any{"jsonrpc": "2.0", "method": "notifications/initialized"}); err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+
+	var list struct {
+		Tools []tool `json:"tools"`
+	}
+	if err := s.call(2, "tools/list", nil, &list); err != nil {
+		t.Fatalf("tools/list: %v", err)
+	}
+	if len(list.Tools) != 2 || list.Tools[0].Name != "echo" || list.Tools[1].Name != ListRulesTool {
+		t.Fatalf("unexpected tools %+v", list.Tools)
+	}
+
+	var res callResult
+	args := map[string]any{"paths": []string{"a.md", "b.md"}, "options": map[string]any{"level": "error"}}
+	if err := s.call(3, "tools/call", map[string]any{"name": "echo", "arguments": args}, &res); err != nil {
+		t.Fatalf("tools/call: %v", err)
+	}
+	if res.IsError || !strings.Contains(res.Content[0].Text, "2 finding(s)") || !strings.Contains(res.Content[0].Text, "a.md: error echo-path saw a.md") {
+		t.Fatalf("unexpected summary %+v", res)
+	}
+
+	args["output"] = "sarif"
+	if err := s.call(4, "tools/call", map[string]any{"name": "echo", "arguments": args}, &res); err != nil {
+		t.Fatalf("tools/call: %v", err)
+	}
+	log, err := sarif.Decode(strings.NewReader(res.Content[0].Text))
+	if err != nil || len(log.Runs[0].Results) != 2 {
+		t.Fatalf("expected SARIF with 2 results, got %v (%v)", log, err)
+	}
+
+	if err := s.call(5, "tools/call", map[string]any{"name": "echo", "arguments": map[string]any{"options": map[string]any{"nope": 1}}}, &res); err != nil || !res.IsError {
+		t.Fatalf("expected unknown option to be a tool error, got %+v (%v)", res, err)
+	}
+
+	if err := s.call(6, "tools/call", map[string]any{"name": ListRulesTool}, &res); err != nil {
+		t.Fatalf("list_rules: %v", err)
+	}
+	if !strings.Contains(res.Content[0].Text, "echo-path") || !strings.Contains(res.Content[0].Text, "Echoes each path") {
+		t.Fatalf("unexpected rules listing %q", res.Content[0].Text)
+	}
+
+	if err := s.call(7, "resources/list", nil, &res); err == nil || err.Code != codeMethodNotFound {
+		t.Fatalf("expected method not found, got %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package mdsanity
+
+import "github.com/dkoosis/lintkit/pkg/sarif"
+
+// ToolName is the SARIF driver name for mdsanity.
+const ToolName = "mdsanity"
+
+var rules = []sarif.ReportingDescriptor{
+	{
+		ID:                   "md-orphan",
+		ShortDescription:     sarif.Text("Markdown file is not reachable from an entry point"),
+		FullDescription:      sarif.Text("No chain of relative links leads from the entry points (README.md at the repository root by default) to this file."),
+		Help:                 sarif.Text("Link the file from an indexed document or remove it."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"},
+	},
+	{
+		ID:                   "md-root-clutter",
+		ShortDescription:     sarif.Text("Markdown file at the repository root"),
+		FullDescription:      sarif.Text("Only README.md, LICENSE.md, and CONTRIBUTING.md belong at the repository root; other documents clutter it."),
+		Help:                 sarif.Text("Move the file under docs/ or another documentation subtree."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"},
+	},
+	{
+		ID:                   "md-ephemeral-placement",
+		ShortDescription:     sarif.Text("Ephemeral document outside a drafts area"),
+		FullDescription:      sarif.Text("Files named like drafts, WIP, scratch, tmp, or notes should live in a dedicated drafts/, notes/, wip/, tmp/, scratch/, or adr/ directory."),
+		Help:                 sarif.Text("Move the file into a drafts or notes directory, or rename it if it is permanent."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"},
+	},
+}
+
+// Rules returns the rule catalog for mdsanity.
+func Rules() []sarif.ReportingDescriptor {
+	return append([]sarif.ReportingDescriptor(nil), rules...)
+}
@@ -12,17 +12,24 @@ import (
 	"strings"
 
 	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/walk"
 )
 
 // Config controls the analysis behavior.
 type Config struct {
 	// RepoRoot is the path to the repository root.
-	RepoRoot string
+	RepoRoot string `yaml:"root"`
 	// EntryPoints are optional markdown files that represent starting points for reachability.
 	// If none are provided, README.md in the repo root is used when present.
-	EntryPoints []string
+	EntryPoints []string `yaml:"entry_points"`
+	// Walk controls how the repository is traversed. Hidden directories,
+	// node_modules, and vendor are always skipped.
+	Walk walk.Options `yaml:"-"`
 }
 
+// defaultExcludeDirs lists directories mdsanity never descends into.
+var defaultExcludeDirs = []string{".*", "node_modules", "vendor"}
+
 // Run executes the markdown hygiene analysis and returns a SARIF log.
 func Run(cfg Config) (*sarif.Log, error) {
 	root, err := filepath.Abs(cfg.RepoRoot)
@@ -30,7 +37,7 @@ func Run(cfg Config) (*sarif.Log, error) {
 		return nil, fmt.Errorf("resolve root: %w", err)
 	}
 
-	mdFiles, err := collectMarkdownFiles(root)
+	mdFiles, err := collectMarkdownFiles(root, cfg.Walk)
 	if err != nil {
 		return nil, err
 	}
@@ -70,10 +77,7 @@ func Run(cfg Config) (*sarif.Log, error) {
 	}
 
 	log := sarif.NewLog()
-	log.Runs = append(log.Runs, sarif.Run{
-		Tool:    sarif.Tool{Driver: sarif.Driver{Name: "mdsanity"}},
-		Results: results,
-	})
+	log.Runs = append(log.Runs, sarif.NewRun(sarif.Driver{Name: ToolName, Rules: Rules()}, results))
 
 	return log, nil
 }
@@ -84,31 +88,18 @@ func makeResult(ruleID, text, relPath string) sarif.Result {
 		Level:     "warning",
 		Message:   sarif.Message{Text: text},
 		Locations: []sarif.Location{locationFor(relPath)},
-	}
+	}.WithFingerprint()
 }
 
 func locationFor(relPath string) sarif.Location {
 	return sarif.Location{PhysicalLocation: sarif.PhysicalLocation{ArtifactLocation: sarif.ArtifactLocation{URI: relPath}}}
 }
 
-func collectMarkdownFiles(root string) (map[string]string, error) {
+func collectMarkdownFiles(root string, opts walk.Options) (map[string]string, error) {
 	files := map[string]string{}
-	skipDirs := map[string]struct{}{".git": {}, "node_modules": {}, "vendor": {}, ".idea": {}, ".vscode": {}}
-
-	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if d.IsDir() {
-			if _, skip := skipDirs[d.Name()]; skip {
-				return filepath.SkipDir
-			}
-			if strings.HasPrefix(d.Name(), ".") && d.Name() != "." {
-				return filepath.SkipDir
-			}
-			return nil
-		}
+	opts.ExcludeDirs = append(append([]string(nil), opts.ExcludeDirs...), defaultExcludeDirs...)
 
+	err := walk.Walk(root, opts, func(path string, d fs.DirEntry) error {
 		if !strings.HasSuffix(strings.ToLower(d.Name()), ".md") {
 			return nil
 		}
@@ -0,0 +1,23 @@
+package nobackups
+
+import "github.com/dkoosis/lintkit/pkg/sarif"
+
+// ToolName is the SARIF driver name for nobackups.
+const ToolName = "lintkit-nobackups"
+
+const ruleID = "nobackups"
+
+var rules = []sarif.ReportingDescriptor{
+	{
+		ID:                   ruleID,
+		ShortDescription:     sarif.Text("Backup or temporary file in the tree"),
+		FullDescription:      sarif.Text("Files ending in .bak, .backup, .old, .orig, .swp, .swo, or ~ are editor or merge leftovers and should not be committed."),
+		Help:                 sarif.Text("Delete the file and add its pattern to .gitignore."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"},
+	},
+}
+
+// Rules returns the rule catalog for nobackups.
+func Rules() []sarif.ReportingDescriptor {
+	return append([]sarif.ReportingDescriptor(nil), rules...)
+}
@@ -10,17 +10,25 @@ import (
 	"strings"
 
 	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/walk"
 )
 
 // Scan walks the provided paths (or the current directory if none are given)
 // and reports backup or temporary files as SARIF results.
 func Scan(paths []string) (*sarif.Log, error) {
+	return ScanWithOptions(paths, walk.Options{})
+}
+
+// ScanWithOptions is like Scan but traverses the paths with the given walk
+// options.
+func ScanWithOptions(paths []string, opts walk.Options) (*sarif.Log, error) {
 	if len(paths) == 0 {
 		paths = []string{"."}
 	}
 
 	walker := &scanner{
 		patterns: defaultPatterns(),
+		opts:     opts,
 	}
 
 	for _, root := range paths {
@@ -35,16 +43,14 @@ func Scan(paths []string) (*sarif.Log, error) {
 	})
 
 	log := sarif.NewLog()
-	log.Runs = []sarif.Run{{
-		Tool:    sarif.Tool{Driver: sarif.Driver{Name: "lintkit-nobackups"}},
-		Results: walker.results,
-	}}
+	log.Runs = []sarif.Run{sarif.NewRun(sarif.Driver{Name: ToolName, Rules: Rules()}, walker.results)}
 
 	return log, nil
 }
 
 type scanner struct {
 	patterns []pattern
+	opts     walk.Options
 	results  []sarif.Result
 }
 
@@ -53,31 +59,25 @@ func (s *scanner) walk(root string) error {
 		return errors.New("empty path provided")
 	}
 
-	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-
-		if d.IsDir() {
-			if d.Name() == ".git" {
-				return filepath.SkipDir
-			}
-			return nil
-		}
-
+	return walk.Walk(root, s.opts, func(path string, d fs.DirEntry) error {
 		if s.isBackup(d.Name()) {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			uri := filepath.ToSlash(path)
 			s.results = append(s.results, sarif.Result{
-				RuleID: "nobackups",
+				RuleID: ruleID,
 				Level:  "warning",
 				Message: sarif.Message{
 					Text: fmt.Sprintf("Backup/temporary file should not be committed: %s", path),
 				},
 				Locations: []sarif.Location{{
 					PhysicalLocation: sarif.PhysicalLocation{
-						ArtifactLocation: sarif.ArtifactLocation{URI: filepath.ToSlash(path)},
+						ArtifactLocation: sarif.ArtifactLocation{URI: uri},
 					},
 				}},
-			})
+			}.WithFingerprint().WithFix("Delete "+uri, sarif.DeleteArtifact(uri, info.Size())))
 		}
 
 		return nil
@@ -0,0 +1,63 @@
+package nuglint
+
+import "github.com/dkoosis/lintkit/pkg/sarif"
+
+// ToolName is the SARIF driver name for nuglint.
+const ToolName = "lintkit-nuglint"
+
+var rules = []sarif.ReportingDescriptor{
+	{
+		ID:                   "nug-json-parse",
+		ShortDescription:     sarif.Text("Line is not valid JSON"),
+		FullDescription:      sarif.Text("Each non-empty line of a nugget JSONL file must be a single JSON object."),
+		Help:                 sarif.Text("Fix the JSON syntax on the reported line."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "error"},
+	},
+	{
+		ID:                   "nug-required-fields",
+		ShortDescription:     sarif.Text("Nugget is missing id, k, or r"),
+		FullDescription:      sarif.Text("Every nugget needs an id, a kind (k), and a rationale (r)."),
+		Help:                 sarif.Text("Add the missing fields to the nugget."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "error"},
+	},
+	{
+		ID:                   "nug-id-format",
+		ShortDescription:     sarif.Text("Nugget id does not follow n:{kind}:{slug}"),
+		FullDescription:      sarif.Text("Nugget ids have the form n:{kind}:{slug}, where kind matches the k field and slug uses lowercase letters, numbers, and hyphens."),
+		Help:                 sarif.Text("Rename the id to n:{kind}:{slug}."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "error"},
+	},
+	{
+		ID:                   "nug-rationale-yaml",
+		ShortDescription:     sarif.Text("Rationale is not a YAML mapping"),
+		FullDescription:      sarif.Text("The r field must hold a YAML mapping of key: value lines."),
+		Help:                 sarif.Text("Rewrite the rationale as key: value lines."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "error"},
+	},
+	{
+		ID:                   "nug-kind-structure",
+		ShortDescription:     sarif.Text("Rationale lacks the keys required for its kind"),
+		FullDescription:      sarif.Text("Each nugget kind (trap, choice, map, cite, spark, check, rule) requires a specific set of rationale keys."),
+		Help:                 sarif.Text("Add the rationale keys listed in the message."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "error"},
+	},
+	{
+		ID:                   "nug-severity-required",
+		ShortDescription:     sarif.Text("Trap nugget has no severity"),
+		FullDescription:      sarif.Text("Trap nuggets must carry a sev field so they can be prioritised."),
+		Help:                 sarif.Text("Add a sev field to the nugget."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "error"},
+	},
+	{
+		ID:                   "nug-orphan",
+		ShortDescription:     sarif.Text("Nugget has no tags"),
+		FullDescription:      sarif.Text("Untagged nuggets are not connected to anything in the knowledge graph and are hard to discover."),
+		Help:                 sarif.Text("Add at least one tag or anchor."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"},
+	},
+}
+
+// Rules returns the rule catalog for nuglint.
+func Rules() []sarif.ReportingDescriptor {
+	return append([]sarif.ReportingDescriptor(nil), rules...)
+}
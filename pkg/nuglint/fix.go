@@ -0,0 +1,51 @@
+package nuglint
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// canonicalID rewrites id to n:{kind}:{slug}, keeping the last segment of
+// id as the slug. It returns "" when no slug remains.
+func canonicalID(id, kind string) string {
+	segments := strings.Split(id, ":")
+	slug := strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(segments[len(segments)-1]), "-"), "-")
+	if slug == "" || kind == "" {
+		return ""
+	}
+	return "n:" + kind + ":" + slug
+}
+
+// withIDFix attaches a fix replacing the nugget's id value on line with its
+// canonical form.
+func withIDFix(r sarif.Result, path string, lineNum int, line string, nug Nug) sarif.Result {
+	fixed := canonicalID(nug.ID, nug.Kind)
+	if fixed == "" || fixed == nug.ID {
+		return r
+	}
+	oldValue, err := json.Marshal(nug.ID)
+	if err != nil {
+		return r
+	}
+	newValue, err := json.Marshal(fixed)
+	if err != nil {
+		return r
+	}
+	pattern := regexp.MustCompile(`"id"\s*:\s*(` + regexp.QuoteMeta(string(oldValue)) + `)`)
+	m := pattern.FindStringSubmatchIndex(line)
+	if m == nil {
+		return r // escaped differently in the source; leave it to the writer
+	}
+	region := sarif.Region{
+		StartLine:   lineNum,
+		StartColumn: sarif.Column(line, m[2]),
+		EndLine:     lineNum,
+		EndColumn:   sarif.Column(line, m[3]),
+	}
+	return r.WithFix("Rewrite id as "+fixed, sarif.Replace(path, region, string(newValue)))
+}
@@ -11,12 +11,22 @@ import (
 	"regexp"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/walk"
+	"github.com/dkoosis/lintkit/pkg/yamlconf"
 )
 
 // Run executes nuglint across the provided paths.
 func Run(paths []string) ([]sarif.Result, error) {
-	files, err := collectFiles(paths)
+	return RunWithOptions(paths, walk.Options{})
+}
+
+// RunWithOptions is like Run but traverses directories with the given walk
+// options.
+func RunWithOptions(paths []string, opts walk.Options) ([]sarif.Result, error) {
+	files, err := collectFiles(paths, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -33,36 +43,10 @@ func Run(paths []string) ([]sarif.Result, error) {
 	return results, nil
 }
 
-func collectFiles(paths []string) ([]string, error) {
-	var files []string
-	for _, p := range paths {
-		info, err := os.Stat(p)
-		if err != nil {
-			return nil, err
-		}
-		if info.IsDir() {
-			err = filepath.WalkDir(p, func(path string, d os.DirEntry, err error) error {
-				if err != nil {
-					return err
-				}
-				if d.IsDir() {
-					return nil
-				}
-				if filepath.Ext(path) == ".jsonl" {
-					files = append(files, path)
-				}
-				return nil
-			})
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			if filepath.Ext(p) == ".jsonl" {
-				files = append(files, p)
-			}
-		}
-	}
-	return files, nil
+func collectFiles(paths []string, opts walk.Options) ([]string, error) {
+	return walk.Files(paths, opts, func(path string) bool {
+		return filepath.Ext(path) == ".jsonl"
+	})
 }
 
 func lintFile(path string) ([]sarif.Result, error) {
@@ -71,9 +55,14 @@ func lintFile(path string) ([]sarif.Result, error) {
 		return nil, err
 	}
 	defer func() { _ = f.Close() }()
+	return Lint(path, f)
+}
 
+// Lint validates the nuggets read from r, reporting findings against path.
+// Editors use it to check unsaved content.
+func Lint(path string, r io.Reader) ([]sarif.Result, error) {
 	var results []sarif.Result
-	reader := bufio.NewReader(f)
+	reader := bufio.NewReader(r)
 	lineNum := 0
 	for {
 		line, err := reader.ReadString('\n')
@@ -81,6 +70,7 @@ func lintFile(path string) ([]sarif.Result, error) {
 			break
 		}
 		lineNum++
+		text := strings.TrimRight(line, "\r\n")
 		line = strings.TrimSpace(line)
 		if line == "" {
 			if errors.Is(err, io.EOF) {
@@ -91,7 +81,7 @@ func lintFile(path string) ([]sarif.Result, error) {
 
 		var raw map[string]any
 		if jsonErr := json.Unmarshal([]byte(line), &raw); jsonErr != nil {
-			results = append(results, result("nug-json-parse", "error", fmt.Sprintf("failed to parse JSON: %v", jsonErr), path, lineNum))
+			results = append(results, result("nug-json-parse", "error", fmt.Sprintf("failed to parse JSON: %v", jsonErr), path, lineNum).WithFingerprint(line))
 			if errors.Is(err, io.EOF) {
 				break
 			}
@@ -99,9 +89,19 @@ func lintFile(path string) ([]sarif.Result, error) {
 		}
 
 		nug := mapToNug(raw)
+		// Nugget IDs are the natural identity; fall back to the raw line when
+		// the ID itself is missing.
+		subject := nug.ID
+		if subject == "" {
+			subject = line
+		}
 		validations := validateNug(nug)
 		for _, v := range validations {
 			v.Locations = []sarif.Location{location(path, lineNum)}
+			v = v.WithFingerprint(subject)
+			if v.RuleID == "nug-id-format" {
+				v = withIDFix(v, path, lineNum, text, nug)
+			}
 			results = append(results, v)
 		}
 
@@ -168,35 +168,26 @@ func validateNug(nug Nug) []sarif.Result {
 	return results
 }
 
+// parseRationale decodes a rationale, which must be a YAML mapping, keyed
+// by normalized field name.
 func parseRationale(r string) (map[string]any, error) {
-	scanner := bufio.NewScanner(strings.NewReader(r))
-	result := map[string]any{}
-	line := 0
-	for scanner.Scan() {
-		line++
-		text := strings.TrimSpace(scanner.Text())
-		if text == "" {
-			continue
-		}
-		if strings.HasPrefix(text, "-") {
-			return nil, fmt.Errorf("rationale YAML must be a mapping")
-		}
-		parts := strings.SplitN(text, ":", 2)
-		if len(parts) != 2 {
-			return nil, fmt.Errorf("rationale is not valid YAML: line %d is not a mapping entry", line)
-		}
-		key := strings.TrimSpace(parts[0])
-		val := strings.TrimSpace(parts[1])
-		if key == "" {
-			return nil, fmt.Errorf("rationale is not valid YAML: line %d has empty key", line)
-		}
-		result[normalizeKey(key)] = val
+	n, err := yamlconf.Parse("", 1, []byte(r))
+	if err != nil {
+		return nil, fmt.Errorf("rationale is not valid YAML: %w", err)
 	}
-	if err := scanner.Err(); err != nil {
+	if n == nil || n.Kind != yaml.MappingNode || len(n.Content) == 0 {
+		return nil, errors.New("rationale YAML must be a mapping")
+	}
+	var fields map[string]any
+	if err := yamlconf.DecodeNode("", n, &fields); err != nil {
 		return nil, fmt.Errorf("rationale is not valid YAML: %w", err)
 	}
-	if len(result) == 0 {
-		return nil, fmt.Errorf("rationale YAML must be a mapping")
+	result := make(map[string]any, len(fields))
+	for key, val := range fields {
+		if strings.TrimSpace(key) == "" {
+			return nil, errors.New("rationale is not valid YAML: empty key")
+		}
+		result[normalizeKey(key)] = val
 	}
 	return result, nil
 }
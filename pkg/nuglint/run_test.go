@@ -128,3 +128,31 @@ func TestOrphan(t *testing.T) {
 		t.Fatalf("expected orphan warning")
 	}
 }
+
+func TestIDMismatchFix(t *testing.T) {
+	if got := canonicalID("n:choice:Leaky Bucket", "trap"); got != "n:trap:leaky-bucket" {
+		t.Fatalf("unexpected canonical id %q", got)
+	}
+
+	content := `{"id": "n:choice:bad","k":"trap","r":"problem: leak\nsymptoms: drip\nworkaround: tape","tags":["ops"],"sev":1}`
+	path := writeTempJSONL(t, content+"\n")
+
+	results, err := Run([]string{path})
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	for _, r := range results {
+		if r.RuleID != "nug-id-format" {
+			continue
+		}
+		if len(r.Fixes) != 1 {
+			t.Fatalf("expected one fix, got %d", len(r.Fixes))
+		}
+		rep := r.Fixes[0].ArtifactChanges[0].Replacements[0]
+		if rep.DeletedRegion.StartColumn != 8 || rep.DeletedRegion.EndColumn != 22 || rep.InsertedContent.Text != `"n:trap:bad"` {
+			t.Fatalf("unexpected replacement: %+v %q", rep.DeletedRegion, rep.InsertedContent.Text)
+		}
+		return
+	}
+	t.Fatalf("expected id format violation")
+}
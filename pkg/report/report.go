@@ -0,0 +1,258 @@
+// Package report renders SARIF logs as a self-contained HTML page for
+// browsing findings without a SARIF viewer.
+package report
+
+import (
+	"bufio"
+	_ "embed"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+//go:embed report.html.tmpl
+var pageTemplate string
+
+var page = template.Must(template.New("report").Parse(pageTemplate))
+
+// Options control HTML rendering.
+type Options struct {
+	// Title is shown in the page heading. It defaults to "lintkit report".
+	Title string
+	// SourceRoot is the directory relative result URIs without a uriBaseId
+	// are resolved against when reading source snippets; URIs with a base
+	// are resolved through the run's originalUriBaseIds. It defaults to the
+	// working directory.
+	SourceRoot string
+	// ContextLines is the number of lines shown around a result's region.
+	ContextLines int
+}
+
+// DefaultContextLines is used when Options.ContextLines is zero.
+const DefaultContextLines = 2
+
+type pageView struct {
+	Title     string
+	Generated string
+	Total     int
+	Levels    []string
+	Tools     []*toolView
+}
+
+type toolView struct {
+	Name  string
+	Count int
+	Rules []*ruleView
+}
+
+type ruleView struct {
+	ID      string
+	Name    string
+	Summary string
+	Help    string
+	HelpURI string
+	Count   int
+	Files   []*fileView
+}
+
+type fileView struct {
+	Path    string
+	Results []resultView
+}
+
+type resultView struct {
+	Tool          string
+	Level         string
+	Line          int
+	Message       string
+	BaselineState string
+	Suppressed    bool
+	Snippet       []snippetLine
+}
+
+type snippetLine struct {
+	Number int
+	Text   string
+	Hit    bool
+}
+
+// WriteHTML renders every result in log, grouped by tool, rule, and file.
+// Suppressed and absent results are included but hidden until the reader
+// asks for them.
+func WriteHTML(w io.Writer, log *sarif.Log, opts Options) error {
+	if opts.Title == "" {
+		opts.Title = "lintkit report"
+	}
+	if opts.SourceRoot == "" {
+		opts.SourceRoot = "."
+	}
+	if opts.ContextLines <= 0 {
+		opts.ContextLines = DefaultContextLines
+	}
+
+	view := pageView{
+		Title:     opts.Title,
+		Generated: time.Now().UTC().Format(time.RFC3339),
+		Levels:    []string{"error", "warning", "note", "none"},
+	}
+	sources := &sourceCache{root: opts.SourceRoot, files: map[string][]string{}}
+
+	tools := map[string]*toolView{}
+	for _, run := range log.Runs {
+		driver := run.Tool.Driver
+		tool := tools[driver.Name]
+		if tool == nil {
+			tool = &toolView{Name: driver.Name}
+			tools[driver.Name] = tool
+			view.Tools = append(view.Tools, tool)
+		}
+		for _, r := range run.Results {
+			rule := tool.rule(driver, r.RuleID)
+			file := rule.file(r.PrimaryURI())
+			file.Results = append(file.Results, newResultView(&run, r, sources, opts.ContextLines))
+			rule.Count++
+			tool.Count++
+			view.Total++
+		}
+	}
+
+	for _, tool := range view.Tools {
+		sort.Slice(tool.Rules, func(i, j int) bool { return tool.Rules[i].ID < tool.Rules[j].ID })
+		for _, rule := range tool.Rules {
+			sort.Slice(rule.Files, func(i, j int) bool { return rule.Files[i].Path < rule.Files[j].Path })
+			for _, file := range rule.Files {
+				sort.SliceStable(file.Results, func(i, j int) bool { return file.Results[i].Line < file.Results[j].Line })
+			}
+		}
+	}
+
+	return page.Execute(w, view)
+}
+
+func (t *toolView) rule(driver sarif.Driver, id string) *ruleView {
+	for _, r := range t.Rules {
+		if r.ID == id {
+			return r
+		}
+	}
+	rv := &ruleView{ID: id}
+	if desc, i := driver.Rule(id); i >= 0 {
+		rv.Name = desc.Name
+		rv.HelpURI = desc.HelpURI
+		if desc.ShortDescription != nil {
+			rv.Summary = desc.ShortDescription.Text
+		}
+		switch {
+		case desc.Help != nil:
+			rv.Help = desc.Help.Text
+		case desc.FullDescription != nil:
+			rv.Help = desc.FullDescription.Text
+		}
+	}
+	t.Rules = append(t.Rules, rv)
+	return rv
+}
+
+func (r *ruleView) file(path string) *fileView {
+	for _, f := range r.Files {
+		if f.Path == path {
+			return f
+		}
+	}
+	f := &fileView{Path: path}
+	r.Files = append(r.Files, f)
+	return f
+}
+
+func newResultView(run *sarif.Run, r sarif.Result, sources *sourceCache, context int) resultView {
+	rv := resultView{
+		Tool:          run.Tool.Driver.Name,
+		Level:         r.Level,
+		Message:       r.Message.Formatted(),
+		BaselineState: r.BaselineState,
+		Suppressed:    !r.IsActive(),
+	}
+	if rv.Level == "" {
+		rv.Level = "warning"
+	}
+	if len(r.Locations) == 0 {
+		return rv
+	}
+	region := r.Locations[0].PhysicalLocation.Region
+	if region == nil || region.StartLine <= 0 {
+		return rv
+	}
+	rv.Line = region.StartLine
+	end := region.EndLine
+	if end < region.StartLine {
+		end = region.StartLine
+	}
+	rv.Snippet = sources.snippet(sources.path(run, r.Locations[0].PhysicalLocation.ArtifactLocation), region.StartLine, end, context)
+	return rv
+}
+
+// sourceCache reads each referenced source file at most once.
+type sourceCache struct {
+	root  string
+	files map[string][]string
+}
+
+// snippet returns lines start-context through end+context of the file at
+// path, marking start through end as hits. It returns nil when the file is
+// unreadable.
+func (c *sourceCache) snippet(path string, start, end, context int) []snippetLine {
+	lines, ok := c.files[path]
+	if !ok {
+		lines = readLines(path)
+		c.files[path] = lines
+	}
+	if start > len(lines) {
+		return nil
+	}
+
+	from := start - context
+	if from < 1 {
+		from = 1
+	}
+	to := end + context
+	if to > len(lines) {
+		to = len(lines)
+	}
+	out := make([]snippetLine, 0, to-from+1)
+	for n := from; n <= to; n++ {
+		out = append(out, snippetLine{Number: n, Text: lines[n-1], Hit: n >= start && n <= end})
+	}
+	return out
+}
+
+// path returns the file a location of run names. Locations the run
+// resolves to an absolute path, through its originalUriBaseIds or a file://
+// URI, are used as is; the rest are taken relative to root.
+func (c *sourceCache) path(run *sarif.Run, loc sarif.ArtifactLocation) string {
+	path := run.LocalPath(loc)
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(c.root, path)
+}
+
+func readLines(path string) []string {
+	f, err := os.Open(path) //nolint:gosec // G304: path comes from SARIF results
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = f.Close() }()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
@@ -0,0 +1,101 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+func TestWriteHTML(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "a.md"), []byte("one\ntwo <b>\nthree\nfour\nfive\nsix\n"), 0o644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+
+	driver := sarif.Driver{Name: "lintkit-wikifmt", Rules: []sarif.ReportingDescriptor{{
+		ID:               "wiki-missing-title",
+		ShortDescription: sarif.Text("Frontmatter lacks a title"),
+		Help:             sarif.Text("Add a title: key to the frontmatter."),
+	}}}
+	log := sarif.NewLog()
+	log.Runs = append(log.Runs, sarif.NewRun(driver, []sarif.Result{{
+		RuleID:  "wiki-missing-title",
+		Level:   "error",
+		Message: sarif.Message{Text: "missing title"},
+		Locations: []sarif.Location{{PhysicalLocation: sarif.PhysicalLocation{
+			ArtifactLocation: sarif.ArtifactLocation{URI: "a.md"},
+			Region:           &sarif.Region{StartLine: 2},
+		}}},
+	}}))
+
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, log, Options{SourceRoot: root}); err != nil {
+		t.Fatalf("WriteHTML: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"lintkit-wikifmt",
+		"Frontmatter lacks a title",
+		"Add a title: key to the frontmatter.",
+		`<span class="hit"><b>2</b>two &lt;b&gt;</span>`,
+		"<b>4</b>four",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected output to contain %q", want)
+		}
+	}
+	if strings.Contains(out, "<b>5</b>") {
+		t.Fatalf("expected snippet to stop after %d context lines", DefaultContextLines)
+	}
+	if strings.Contains(out, "<link") || strings.Contains(out, "<script src") {
+		t.Fatalf("expected no external assets")
+	}
+}
+
+func TestWriteHTMLResolvesURIBases(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "my docs")
+	if err := os.MkdirAll(filepath.Join(root, "wiki"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "wiki", "a.md"), []byte("alpha line\n"), 0o644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "b.md"), []byte("beta line\n"), 0o644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+
+	at := func(loc sarif.ArtifactLocation) sarif.Result {
+		return sarif.Result{
+			RuleID:  "r",
+			Message: sarif.Message{Text: "m"},
+			Locations: []sarif.Location{{PhysicalLocation: sarif.PhysicalLocation{
+				ArtifactLocation: loc,
+				Region:           &sarif.Region{StartLine: 1},
+			}}},
+		}
+	}
+	run := sarif.NewRun(sarif.Driver{Name: "t"}, []sarif.Result{
+		at(sarif.ArtifactLocation{URI: "wiki/a.md", URIBaseID: sarif.SrcRoot}),
+		at(sarif.ArtifactLocation{URI: sarif.FileURI(filepath.Join(root, "b.md"))}),
+	})
+	run.OriginalURIBaseIDs = map[string]sarif.ArtifactLocation{sarif.SrcRoot: {URI: sarif.FileURI(root) + "/"}}
+	log := sarif.NewLog()
+	log.Runs = append(log.Runs, run)
+
+	// The source root would not find either file; the run's base and the
+	// percent-encoded file:// URI must.
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, log, Options{SourceRoot: t.TempDir()}); err != nil {
+		t.Fatalf("WriteHTML: %v", err)
+	}
+	for _, want := range []string{"alpha line", "beta line"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("expected snippet %q in output", want)
+		}
+	}
+}
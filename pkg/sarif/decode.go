@@ -0,0 +1,25 @@
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Decode reads a SARIF log. Members lintkit does not model are kept in the
+// Extra fields of the log, its runs, drivers, rules, results, and
+// locations, and are written back when the log is encoded; logs must
+// declare version 2.1.0.
+func Decode(r io.Reader) (*Log, error) {
+	var log Log
+	if err := json.NewDecoder(r).Decode(&log); err != nil {
+		return nil, fmt.Errorf("decode SARIF: %w", err)
+	}
+	if log.Version != Version {
+		return nil, fmt.Errorf("unsupported SARIF version %q (want %s)", log.Version, Version)
+	}
+	if log.Runs == nil {
+		log.Runs = []Run{}
+	}
+	return &log, nil
+}
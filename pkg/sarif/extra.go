@@ -0,0 +1,206 @@
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Extra holds the members of a SARIF object that lintkit does not model,
+// such as properties, artifacts, or codeFlows, as decoded JSON. Types with
+// an Extra field write its members back when encoded, so logs from other
+// tools pass through lintkit without loss.
+type Extra map[string]json.RawMessage
+
+// marshalExtra encodes v, an object without JSON methods, followed by the
+// members of extra in name order. HTML characters are left unescaped; the
+// encoder that called the method decides whether to escape them.
+func marshalExtra(v interface{}, extra Extra) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	data := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+	if len(extra) == 0 {
+		return data, nil
+	}
+	names := make([]string, 0, len(extra))
+	for name := range extra {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	b.Write(data[:len(data)-1]) // drop the closing brace
+	for _, name := range names {
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(extra[name])
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// unmarshalExtra decodes data into v, a pointer to an object without JSON
+// methods, and returns the members its type has no field for.
+func unmarshalExtra(data []byte, v interface{}) (Extra, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil || members == nil {
+		return nil, err
+	}
+	known := jsonNames(reflect.TypeOf(v).Elem())
+	extra := Extra{}
+	for name, raw := range members {
+		if !known[strings.ToLower(name)] {
+			extra[name] = raw
+		}
+	}
+	if len(extra) == 0 {
+		return nil, nil
+	}
+	return extra, nil
+}
+
+// jsonNames returns the lower-cased member names encoding/json uses for
+// the fields of the struct type t; it matches names without regard to case.
+func jsonNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[strings.ToLower(name)] = true
+	}
+	return names
+}
+
+// MarshalJSON encodes the log with its extra members.
+func (l Log) MarshalJSON() ([]byte, error) {
+	type plain Log
+	return marshalExtra(plain(l), l.Extra)
+}
+
+// UnmarshalJSON decodes the log, keeping members lintkit does not model.
+func (l *Log) UnmarshalJSON(data []byte) error {
+	type plain Log
+	extra, err := unmarshalExtra(data, (*plain)(l))
+	l.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the run with its extra members.
+func (r Run) MarshalJSON() ([]byte, error) {
+	type plain Run
+	return marshalExtra(plain(r), r.Extra)
+}
+
+// UnmarshalJSON decodes the run, keeping members lintkit does not model.
+func (r *Run) UnmarshalJSON(data []byte) error {
+	type plain Run
+	extra, err := unmarshalExtra(data, (*plain)(r))
+	r.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the driver with its extra members.
+func (d Driver) MarshalJSON() ([]byte, error) {
+	type plain Driver
+	return marshalExtra(plain(d), d.Extra)
+}
+
+// UnmarshalJSON decodes the driver, keeping members lintkit does not model.
+func (d *Driver) UnmarshalJSON(data []byte) error {
+	type plain Driver
+	extra, err := unmarshalExtra(data, (*plain)(d))
+	d.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the rule descriptor with its extra members.
+func (d ReportingDescriptor) MarshalJSON() ([]byte, error) {
+	type plain ReportingDescriptor
+	return marshalExtra(plain(d), d.Extra)
+}
+
+// UnmarshalJSON decodes the rule descriptor, keeping members lintkit does
+// not model.
+func (d *ReportingDescriptor) UnmarshalJSON(data []byte) error {
+	type plain ReportingDescriptor
+	extra, err := unmarshalExtra(data, (*plain)(d))
+	d.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the result with its extra members.
+func (r Result) MarshalJSON() ([]byte, error) {
+	type plain Result
+	return marshalExtra(plain(r), r.Extra)
+}
+
+// UnmarshalJSON decodes the result, keeping members lintkit does not model.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	type plain Result
+	extra, err := unmarshalExtra(data, (*plain)(r))
+	r.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the location with its extra members.
+func (l Location) MarshalJSON() ([]byte, error) {
+	type plain Location
+	return marshalExtra(plain(l), l.Extra)
+}
+
+// UnmarshalJSON decodes the location, keeping members lintkit does not
+// model.
+func (l *Location) UnmarshalJSON(data []byte) error {
+	type plain Location
+	extra, err := unmarshalExtra(data, (*plain)(l))
+	l.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the physical location with its extra members.
+func (l PhysicalLocation) MarshalJSON() ([]byte, error) {
+	type plain PhysicalLocation
+	return marshalExtra(plain(l), l.Extra)
+}
+
+// UnmarshalJSON decodes the physical location, keeping members lintkit
+// does not model.
+func (l *PhysicalLocation) UnmarshalJSON(data []byte) error {
+	type plain PhysicalLocation
+	extra, err := unmarshalExtra(data, (*plain)(l))
+	l.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the region with its extra members.
+func (r Region) MarshalJSON() ([]byte, error) {
+	type plain Region
+	return marshalExtra(plain(r), r.Extra)
+}
+
+// UnmarshalJSON decodes the region, keeping members lintkit does not model.
+func (r *Region) UnmarshalJSON(data []byte) error {
+	type plain Region
+	extra, err := unmarshalExtra(data, (*plain)(r))
+	r.Extra = extra
+	return err
+}
@@ -0,0 +1,79 @@
+package sarif
+
+import "unicode/utf16"
+
+// DeleteProperty marks an artifact change that removes the whole artifact,
+// which SARIF replacements cannot express on their own.
+const DeleteProperty = "lintkit/deleteArtifact"
+
+// Fix is a proposed correction for a result: a set of changes to be
+// applied together.
+type Fix struct {
+	Description     *Message         `json:"description,omitempty"`
+	ArtifactChanges []ArtifactChange `json:"artifactChanges"`
+}
+
+// ArtifactChange lists the replacements to make in one artifact.
+type ArtifactChange struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Replacements     []Replacement    `json:"replacements"`
+	Properties       map[string]any   `json:"properties,omitempty"`
+}
+
+// Replacement replaces a region of an artifact with new content. An empty
+// deleted region inserts the content at its start.
+type Replacement struct {
+	DeletedRegion   Region           `json:"deletedRegion"`
+	InsertedContent *ArtifactContent `json:"insertedContent,omitempty"`
+}
+
+// ArtifactContent is the textual content of an artifact or part of one.
+type ArtifactContent struct {
+	Text string `json:"text"`
+}
+
+// WithFix returns a copy of r carrying an additional fix made of changes.
+func (r Result) WithFix(description string, changes ...ArtifactChange) Result {
+	fixes := make([]Fix, len(r.Fixes), len(r.Fixes)+1)
+	copy(fixes, r.Fixes)
+	r.Fixes = append(fixes, Fix{Description: &Message{Text: description}, ArtifactChanges: changes})
+	return r
+}
+
+// Replace returns a change replacing region of uri with text.
+func Replace(uri string, region Region, text string) ArtifactChange {
+	rep := Replacement{DeletedRegion: region}
+	if text != "" {
+		rep.InsertedContent = &ArtifactContent{Text: text}
+	}
+	return ArtifactChange{ArtifactLocation: ArtifactLocation{URI: uri}, Replacements: []Replacement{rep}}
+}
+
+// Insert returns a change inserting text before the given one-based line,
+// which may be one past the last line to append.
+func Insert(uri string, line int, text string) ArtifactChange {
+	return Replace(uri, Region{StartLine: line, StartColumn: 1, EndLine: line, EndColumn: 1}, text)
+}
+
+// DeleteArtifact returns a change removing uri, whose content is size
+// bytes long.
+func DeleteArtifact(uri string, size int64) ArtifactChange {
+	offset := 0
+	return ArtifactChange{
+		ArtifactLocation: ArtifactLocation{URI: uri},
+		Replacements:     []Replacement{{DeletedRegion: Region{ByteOffset: &offset, ByteLength: int(size)}}},
+		Properties:       map[string]any{DeleteProperty: true},
+	}
+}
+
+// DeletesArtifact reports whether the change removes its artifact.
+func (c ArtifactChange) DeletesArtifact() bool {
+	v, _ := c.Properties[DeleteProperty].(bool)
+	return v
+}
+
+// Column converts a byte offset within line to a one-based SARIF column,
+// which counts UTF-16 code units.
+func Column(line string, byteOffset int) int {
+	return len(utf16.Encode([]rune(line[:byteOffset]))) + 1
+}
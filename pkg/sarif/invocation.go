@@ -0,0 +1,41 @@
+package sarif
+
+import "time"
+
+// Invocation describes how a run's tool was invoked and how it ended.
+type Invocation struct {
+	CommandLine                string                  `json:"commandLine,omitempty"`
+	StartTimeUTC               time.Time               `json:"startTimeUtc,omitzero"`
+	EndTimeUTC                 time.Time               `json:"endTimeUtc,omitzero"`
+	ExitCode                   *int                    `json:"exitCode,omitempty"`
+	ExecutionSuccessful        bool                    `json:"executionSuccessful"`
+	WorkingDirectory           *ArtifactLocation       `json:"workingDirectory,omitempty"`
+	RuleConfigurationOverrides []ConfigurationOverride `json:"ruleConfigurationOverrides,omitempty"`
+	ToolExecutionNotifications []Notification          `json:"toolExecutionNotifications,omitempty"`
+}
+
+// Notification reports a condition met while running the tool, such as
+// the error that made it fail.
+type Notification struct {
+	Level   string  `json:"level,omitempty"` // error, warning, note
+	Message Message `json:"message"`
+}
+
+// SetExitCode records code as the exit code of every invocation in log.
+func SetExitCode(log *Log, code int) {
+	for i := range log.Runs {
+		for j := range log.Runs[i].Invocations {
+			c := code
+			log.Runs[i].Invocations[j].ExitCode = &c
+		}
+	}
+}
+
+// SetCommandLine records commandLine on every invocation in log.
+func SetCommandLine(log *Log, commandLine string) {
+	for i := range log.Runs {
+		for j := range log.Runs[i].Invocations {
+			log.Runs[i].Invocations[j].CommandLine = commandLine
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package sarif
+
+// Merge combines the runs of several logs into a single log. Runs are kept
+// as they are, in argument order; each extra log member is taken from the
+// first log that has it.
+func Merge(logs ...*Log) *Log {
+	merged := NewLog()
+	for _, log := range logs {
+		if log == nil {
+			continue
+		}
+		merged.Runs = append(merged.Runs, log.Runs...)
+		for name, raw := range log.Extra {
+			if _, ok := merged.Extra[name]; ok {
+				continue
+			}
+			if merged.Extra == nil {
+				merged.Extra = Extra{}
+			}
+			merged.Extra[name] = raw
+		}
+	}
+	return merged
+}
+
+// Filter returns a copy of log containing only the results for which keep
+// returns true. Runs are preserved even when all of their results are
+// dropped, so the log still records which tools ran.
+func Filter(log *Log, keep func(run *Run, r Result) bool) *Log {
+	out := NewLog()
+	out.Extra = log.Extra
+	for i := range log.Runs {
+		run := log.Runs[i]
+		run.Results = nil
+		for _, r := range log.Runs[i].Results {
+			if keep(&log.Runs[i], r) {
+				run.Results = append(run.Results, r)
+			}
+		}
+		run.IndexRules()
+		out.Runs = append(out.Runs, run)
+	}
+	return out
+}
+
+// Diff compares two logs and returns the results of newer with
+// baselineState set: "unchanged" when an equivalent result occurs in older,
+// "new" otherwise. Results of older without a counterpart are appended as
+// "absent". Results are matched per tool by StableFingerprint; repeated
+// fingerprints are matched one for one.
+func Diff(older, newer *Log) *Log {
+	remaining := map[string]map[string][]Result{}
+	var order []string
+	for _, run := range older.Runs {
+		tool := run.Tool.Driver.Name
+		if remaining[tool] == nil {
+			remaining[tool] = map[string][]Result{}
+			order = append(order, tool)
+		}
+		for _, r := range run.Results {
+			if !r.IsActive() {
+				continue
+			}
+			fp := r.StableFingerprint()
+			remaining[tool][fp] = append(remaining[tool][fp], r)
+		}
+	}
+
+	out := NewLog()
+	out.Extra = newer.Extra
+	first := map[string]int{}
+	for _, run := range newer.Runs {
+		tool := run.Tool.Driver.Name
+		old := remaining[tool]
+		results := make([]Result, 0, len(run.Results))
+		for _, r := range run.Results {
+			if !r.IsActive() {
+				continue
+			}
+			fp := r.StableFingerprint()
+			if matches := old[fp]; len(matches) > 0 {
+				old[fp] = matches[1:]
+				r.BaselineState = "unchanged"
+			} else {
+				r.BaselineState = "new"
+			}
+			results = append(results, r)
+		}
+		if _, ok := first[tool]; !ok {
+			first[tool] = len(out.Runs)
+		}
+		run.Results = results
+		out.Runs = append(out.Runs, run)
+	}
+
+	// Unmatched older results go to the tool's first run in newer, or to a
+	// copy of its older run when the tool did not run again.
+	for _, tool := range order {
+		gone := absent(older, tool, remaining[tool])
+		if i, ok := first[tool]; ok {
+			out.Runs[i].Results = append(out.Runs[i].Results, gone...)
+			continue
+		}
+		for _, run := range older.Runs {
+			if run.Tool.Driver.Name == tool {
+				run.Results = gone
+				out.Runs = append(out.Runs, run)
+				break
+			}
+		}
+	}
+	for i := range out.Runs {
+		out.Runs[i].IndexRules()
+	}
+	return out
+}
+
+// absent returns the unmatched results of tool in older order, marked
+// absent.
+func absent(older *Log, tool string, unmatched map[string][]Result) []Result {
+	var results []Result
+	for _, run := range older.Runs {
+		if run.Tool.Driver.Name != tool {
+			continue
+		}
+		for _, r := range run.Results {
+			fp := r.StableFingerprint()
+			if !r.IsActive() || len(unmatched[fp]) == 0 {
+				continue
+			}
+			unmatched[fp] = unmatched[fp][1:]
+			r.BaselineState = "absent"
+			results = append(results, r)
+		}
+	}
+	return results
+}
@@ -0,0 +1,360 @@
+package sarif_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+func logWith(tool string, results ...sarif.Result) *sarif.Log {
+	log := sarif.NewLog()
+	log.Runs = append(log.Runs, sarif.NewRun(sarif.Driver{Name: tool}, results))
+	return log
+}
+
+func result(rule, uri, text string) sarif.Result {
+	return sarif.Result{
+		RuleID:  rule,
+		Message: sarif.Message{Text: text},
+		Locations: []sarif.Location{{
+			PhysicalLocation: sarif.PhysicalLocation{ArtifactLocation: sarif.ArtifactLocation{URI: uri}},
+		}},
+	}
+}
+
+func TestDecode_RoundTripsEncodedLog(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	if err := sarif.NewEncoder(buf).Encode(logWith("tool", result("R1", "a.go", "msg"))); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	log, err := sarif.Decode(buf)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(log.Runs) != 1 || log.Runs[0].Results[0].PrimaryURI() != "a.go" {
+		t.Fatalf("unexpected log: %+v", log)
+	}
+
+	if _, err := sarif.Decode(strings.NewReader(`{"version":"1.0.0","runs":[]}`)); err == nil {
+		t.Fatalf("expected unsupported version error")
+	}
+}
+
+func TestDecode_KeepsUnmodeledMembers(t *testing.T) {
+	t.Parallel()
+
+	in := `{
+  "version": "2.1.0",
+  "inlineExternalProperties": [{"guid": "g"}],
+  "runs": [{
+    "tool": {"driver": {"name": "other", "semanticVersion": "1.2.3", "rules": [{"id": "X1", "properties": {"tags": ["security"]}}]}},
+    "artifacts": [{"location": {"uri": "a.c"}, "length": 10}],
+    "properties": {"run": 1},
+    "results": [{
+      "ruleId": "X1",
+      "message": {"text": "m"},
+      "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.c"}, "region": {"startLine": 2, "snippet": {"text": "x = y"}}, "contextRegion": {"startLine": 1}}, "logicalLocations": [{"name": "main"}]}],
+      "codeFlows": [{"threadFlows": []}],
+      "properties": {"confidence": "high"}
+    }]
+  }]
+}`
+	log, err := sarif.Decode(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	log = sarif.Filter(sarif.Merge(log), func(*sarif.Run, sarif.Result) bool { return true })
+
+	buf := &bytes.Buffer{}
+	if err := sarif.NewEncoder(buf).Encode(log); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`"inlineExternalProperties"`,
+		`"semanticVersion": "1.2.3"`,
+		`"security"`,
+		`"artifacts"`,
+		`"run": 1`,
+		`"x = y"`,
+		`"contextRegion"`,
+		`"logicalLocations"`,
+		`"codeFlows"`,
+		`"confidence": "high"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %s to survive decode and encode:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, `"ruleId"`) != 1 || strings.Count(out, `"startLine": 2`) != 1 {
+		t.Fatalf("modeled members duplicated:\n%s", out)
+	}
+}
+
+func TestFilter_KeepsRunsWithoutResults(t *testing.T) {
+	t.Parallel()
+
+	log := sarif.Merge(
+		logWith("a", result("R1", "a.go", "one"), result("R2", "b.go", "two")),
+		logWith("b", result("R3", "c.go", "three")),
+	)
+	out := sarif.Filter(log, func(_ *sarif.Run, r sarif.Result) bool { return r.RuleID == "R2" })
+
+	if len(out.Runs) != 2 {
+		t.Fatalf("expected both runs, got %d", len(out.Runs))
+	}
+	if len(out.Runs[0].Results) != 1 || out.Runs[0].Results[0].RuleID != "R2" || len(out.Runs[1].Results) != 0 {
+		t.Fatalf("unexpected results: %+v", out.Runs)
+	}
+	if len(log.Runs[0].Results) != 2 {
+		t.Fatalf("Filter must not modify its input")
+	}
+}
+
+func TestDiff_MarksNewUnchangedAndAbsent(t *testing.T) {
+	t.Parallel()
+
+	older := sarif.Merge(
+		logWith("a", result("R1", "a.go", "kept"), result("R2", "a.go", "fixed")),
+		logWith("gone", result("R9", "z.go", "tool removed")),
+	)
+	newer := logWith("a", result("R1", "a.go", "kept"), result("R3", "a.go", "added"))
+
+	diff := sarif.Diff(older, newer)
+	states := map[string]string{}
+	for _, run := range diff.Runs {
+		for _, r := range run.Results {
+			states[r.RuleID] = r.BaselineState
+		}
+	}
+
+	want := map[string]string{"R1": "unchanged", "R2": "absent", "R3": "new", "R9": "absent"}
+	for rule, state := range want {
+		if states[rule] != state {
+			t.Fatalf("%s: got %q, want %q (all: %v)", rule, states[rule], state, states)
+		}
+	}
+}
+
+func TestOverrides_ApplyAndRecordInInvocations(t *testing.T) {
+	t.Parallel()
+
+	log := sarif.NewLog()
+	log.Runs = append(log.Runs,
+		sarif.NewRun(sarif.Driver{Name: "a", Rules: []sarif.ReportingDescriptor{{ID: "R1"}, {ID: "R2"}}},
+			[]sarif.Result{result("R1", "a.go", "one"), result("R2", "b.go", "two")}),
+		sarif.NewRun(sarif.Driver{Name: "b"}, []sarif.Result{result("R3", "c.go", "three")}),
+	)
+	sarif.Overrides{"R1": sarif.LevelOff, "R2": "error", "R9": "note"}.Apply(log)
+
+	a := log.Runs[0]
+	if len(a.Results) != 1 || a.Results[0].RuleID != "R2" || a.Results[0].Level != "error" {
+		t.Fatalf("unexpected results: %+v", a.Results)
+	}
+	if len(a.Invocations) != 1 || !a.Invocations[0].ExecutionSuccessful {
+		t.Fatalf("expected one successful invocation, got %+v", a.Invocations)
+	}
+	overrides := a.Invocations[0].RuleConfigurationOverrides
+	if len(overrides) != 2 {
+		t.Fatalf("expected overrides for R1 and R2 only, got %+v", overrides)
+	}
+	off := overrides[0]
+	if off.Descriptor.ID != "R1" || *off.Descriptor.Index != 0 || off.Configuration.Enabled == nil || *off.Configuration.Enabled {
+		t.Fatalf("unexpected R1 override: %+v", off)
+	}
+	if overrides[1].Descriptor.ID != "R2" || overrides[1].Configuration.Level != "error" {
+		t.Fatalf("unexpected R2 override: %+v", overrides[1])
+	}
+	if b := log.Runs[1]; len(b.Invocations) != 0 || len(b.Results) != 1 {
+		t.Fatalf("run without overridden rules must be untouched: %+v", b)
+	}
+}
+
+func TestRelativize_MakesLocationsRelativeToSourceRoot(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "docs"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	outside := filepath.Join(t.TempDir(), "x.md")
+	log := logWith("t",
+		result("r", filepath.Join(root, "docs", "a.md"), "file"),
+		result("r", filepath.Join(root, "docs"), "dir"),
+		result("r", outside, "outside"),
+	)
+	log.Runs[0].Results[0].RelatedLocations = []sarif.Location{sarif.Related(1, filepath.Join(root, "docs", "b.md"), 3, "copy")}
+	if err := sarif.Relativize(log, root); err != nil {
+		t.Fatalf("Relativize: %v", err)
+	}
+
+	run := &log.Runs[0]
+	base, ok := run.OriginalURIBaseIDs[sarif.SrcRoot]
+	if !ok || !strings.HasPrefix(base.URI, "file:///") || !strings.HasSuffix(base.URI, "/") {
+		t.Fatalf("unexpected base: %+v", run.OriginalURIBaseIDs)
+	}
+	want := []sarif.ArtifactLocation{
+		{URI: "docs/a.md", URIBaseID: sarif.SrcRoot},
+		{URI: "docs/", URIBaseID: sarif.SrcRoot},
+		{URI: sarif.FileURI(outside)},
+	}
+	for i, w := range want {
+		if got := run.Results[i].Locations[0].PhysicalLocation.ArtifactLocation; got != w {
+			t.Fatalf("result %d: got %+v, want %+v", i, got, w)
+		}
+	}
+	if got := run.Results[0].RelatedLocations[0].PhysicalLocation.ArtifactLocation; got.URI != "docs/b.md" || got.URIBaseID != sarif.SrcRoot {
+		t.Fatalf("related location not relativized: %+v", got)
+	}
+	if got := run.PrimaryPath(run.Results[0]); got != filepath.Join(root, "docs", "a.md") {
+		t.Fatalf("PrimaryPath: got %q", got)
+	}
+	if got := run.PrimaryPath(run.Results[2]); got != outside {
+		t.Fatalf("PrimaryPath outside root: got %q", got)
+	}
+}
+
+func TestRelativize_FingerprintsDoNotDependOnPathForm(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "docs"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	// The same file linted as `docs` from the root, as `.` from docs, and
+	// by absolute path, with the root as the source root each time.
+	forms := []struct{ dir, root, path string }{
+		{root, ".", filepath.Join("docs", "a.md")},
+		{filepath.Join(root, "docs"), "..", "a.md"},
+		{root, root, filepath.Join(root, "docs", "a.md")},
+	}
+	want := sarif.Fingerprint("r", "docs/a.md", "subject")
+	for i, f := range forms {
+		t.Chdir(f.dir)
+		r := result("r", f.path, "text").WithFingerprint("subject")
+
+		log := logWith("t", r)
+		if err := sarif.Relativize(log, f.root); err != nil {
+			t.Fatalf("Relativize: %v", err)
+		}
+		got := log.Runs[0].Results[0].PartialFingerprints[sarif.FingerprintKey]
+
+		run := sarif.Run{Tool: sarif.Tool{Driver: sarif.Driver{Name: "t"}}}
+		if err := run.Relativize(f.root); err != nil {
+			t.Fatalf("Relativize: %v", err)
+		}
+		buf := &bytes.Buffer{}
+		w := sarif.NewWriter(buf)
+		if err := w.BeginRun(run); err != nil {
+			t.Fatalf("BeginRun: %v", err)
+		}
+		if err := w.WriteResult(r); err != nil {
+			t.Fatalf("WriteResult: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+		streamed, err := sarif.Decode(buf)
+		if err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		if fp := streamed.Runs[0].Results[0].PartialFingerprints[sarif.FingerprintKey]; fp != got {
+			t.Fatalf("form %d: streamed fingerprint %s, relativized %s", i, fp, got)
+		}
+
+		if got != want {
+			t.Fatalf("form %d (%s): fingerprint %s, want %s", i, f.path, got, want)
+		}
+	}
+}
+
+func TestWriter_MatchesEncoder(t *testing.T) {
+	t.Parallel()
+
+	driver := sarif.Driver{Name: "t", Rules: []sarif.ReportingDescriptor{{ID: "r"}}}
+	first := sarif.NewRun(driver, []sarif.Result{result("r", "a.md", "one"), result("x", "b.md", "two")})
+	first.Invocations = []sarif.Invocation{{ExecutionSuccessful: true}}
+	log := sarif.NewLog()
+	log.Runs = append(log.Runs, first, sarif.NewRun(sarif.Driver{Name: "empty"}, nil))
+
+	want := &bytes.Buffer{}
+	if err := sarif.NewEncoder(want).Encode(log); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	got := &bytes.Buffer{}
+	w := sarif.NewWriter(got)
+	if err := w.BeginRun(sarif.Run{Tool: first.Tool, Invocations: first.Invocations}); err != nil {
+		t.Fatalf("BeginRun: %v", err)
+	}
+	for _, r := range first.Results {
+		r.RuleIndex = nil
+		if err := w.WriteResult(r); err != nil {
+			t.Fatalf("WriteResult: %v", err)
+		}
+	}
+	if err := w.EndRun(); err != nil {
+		t.Fatalf("EndRun: %v", err)
+	}
+	if err := w.BeginRun(log.Runs[1]); err != nil {
+		t.Fatalf("BeginRun: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if got.String() != want.String() {
+		t.Fatalf("streamed log differs:\n%s\nwant:\n%s", got, want)
+	}
+
+	empty := &bytes.Buffer{}
+	if err := sarif.NewWriter(empty).Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := sarif.Decode(empty); err != nil {
+		t.Fatalf("empty log does not decode: %v", err)
+	}
+}
+
+func TestWriter_RelativizesAndWritesTrailingInvocations(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	run := sarif.Run{Tool: sarif.Tool{Driver: sarif.Driver{Name: "t"}}}
+	if err := run.Relativize(root); err != nil {
+		t.Fatalf("Relativize: %v", err)
+	}
+	buf := &bytes.Buffer{}
+	w := sarif.NewWriter(buf)
+	if err := w.BeginRun(run); err != nil {
+		t.Fatalf("BeginRun: %v", err)
+	}
+	if err := w.WriteResult(result("r", filepath.Join(root, "a.md"), "one")); err != nil {
+		t.Fatalf("WriteResult: %v", err)
+	}
+	if err := w.EndRun(sarif.Invocation{ExecutionSuccessful: true}); err != nil {
+		t.Fatalf("EndRun: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	log, err := sarif.Decode(buf)
+	if err != nil {
+		t.Fatalf("Decode: %v\n%s", err, buf)
+	}
+	got := log.Runs[0]
+	if loc := got.Results[0].Locations[0].PhysicalLocation.ArtifactLocation; loc.URI != "a.md" || loc.URIBaseID != sarif.SrcRoot {
+		t.Fatalf("unexpected location: %+v", loc)
+	}
+	if len(got.Invocations) != 1 || !got.Invocations[0].ExecutionSuccessful {
+		t.Fatalf("unexpected invocations: %+v", got.Invocations)
+	}
+	if err := w.WriteResult(result("r", "a.md", "late")); err == nil {
+		t.Fatalf("expected WriteResult after Close to fail")
+	}
+}
@@ -0,0 +1,109 @@
+package sarif
+
+import "sort"
+
+// LevelOff disables a rule in Overrides.
+const LevelOff = "off"
+
+// ConfigurationOverride records a rule configuration that differs from the
+// rule's default for one invocation.
+type ConfigurationOverride struct {
+	Descriptor    ReportingDescriptorReference `json:"descriptor"`
+	Configuration ReportingConfiguration       `json:"configuration"`
+}
+
+// ReportingDescriptorReference points at a rule of the run's driver by ID
+// and, when the driver describes it, by index.
+type ReportingDescriptorReference struct {
+	ID    string `json:"id,omitempty"`
+	Index *int   `json:"index,omitempty"`
+}
+
+// Overrides maps rule IDs to the level their results are reported at, or
+// to LevelOff to drop them.
+type Overrides map[string]string
+
+// Results returns results with the overrides applied. Results of disabled
+// rules are dropped; the slice is filtered in place.
+func (o Overrides) Results(results []Result) []Result {
+	if len(o) == 0 {
+		return results
+	}
+	kept := results[:0]
+	for _, r := range results {
+		if o.Result(&r) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// Result applies the overrides to r and reports whether it is kept, that
+// is, whether its rule is not disabled.
+func (o Overrides) Result(r *Result) bool {
+	level, ok := o[r.RuleID]
+	switch {
+	case !ok:
+	case level == LevelOff:
+		return false
+	default:
+		r.Level = level
+	}
+	return true
+}
+
+// Apply applies the overrides to every run of log. The overrides for rules
+// a run describes or reports are recorded in its invocations, adding an
+// invocation when the run has none.
+func (o Overrides) Apply(log *Log) {
+	for i := range log.Runs {
+		run := &log.Runs[i]
+		reported := map[string]bool{}
+		for _, r := range run.Results {
+			reported[r.RuleID] = true
+		}
+
+		recorded := o.Configurations(run.Tool.Driver, reported)
+		if len(recorded) == 0 {
+			continue
+		}
+
+		run.Results = o.Results(run.Results)
+		if len(run.Invocations) == 0 {
+			run.Invocations = []Invocation{{ExecutionSuccessful: true}}
+		}
+		for j := range run.Invocations {
+			inv := &run.Invocations[j]
+			inv.RuleConfigurationOverrides = append(inv.RuleConfigurationOverrides, recorded...)
+		}
+	}
+}
+
+// Configurations returns the overrides for the rules driver describes or
+// that are reported, in rule ID order, as recorded in an invocation.
+func (o Overrides) Configurations(driver Driver, reported map[string]bool) []ConfigurationOverride {
+	ids := make([]string, 0, len(o))
+	for id := range o {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var recorded []ConfigurationOverride
+	for _, id := range ids {
+		_, idx := driver.Rule(id)
+		if idx < 0 && !reported[id] {
+			continue
+		}
+		ref := ReportingDescriptorReference{ID: id}
+		if idx >= 0 {
+			ref.Index = &idx
+		}
+		cfg := ReportingConfiguration{Level: o[id]}
+		if o[id] == LevelOff {
+			disabled := false
+			cfg = ReportingConfiguration{Enabled: &disabled}
+		}
+		recorded = append(recorded, ConfigurationOverride{Descriptor: ref, Configuration: cfg})
+	}
+	return recorded
+}
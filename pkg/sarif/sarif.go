@@ -2,24 +2,39 @@
 package sarif
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
 )
 
 // Version is the SARIF schema version.
 const Version = "2.1.0"
 
+// FingerprintKey is the partialFingerprints entry holding lintkit's
+// content-based fingerprint.
+const FingerprintKey = "lintkit/v1"
+
 // Log is the top-level SARIF structure.
 type Log struct {
 	Version string `json:"version"`
 	Schema  string `json:"$schema,omitempty"`
 	Runs    []Run  `json:"runs"`
+	Extra   Extra  `json:"-"`
 }
 
 // Run represents a single analysis run.
 type Run struct {
-	Tool    Tool     `json:"tool"`
-	Results []Result `json:"results,omitempty"`
+	Tool        Tool         `json:"tool"`
+	Invocations []Invocation `json:"invocations,omitempty"`
+	// OriginalURIBaseIDs maps the uriBaseId of artifact locations, such as
+	// SrcRoot, to the absolute location they are relative to.
+	OriginalURIBaseIDs map[string]ArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []Result                    `json:"results,omitempty"`
+	Extra              Extra                       `json:"-"`
 }
 
 // Tool describes the analysis tool.
@@ -29,46 +44,155 @@ type Tool struct {
 
 // Driver describes the tool's identity.
 type Driver struct {
-	Name           string `json:"name"`
-	Version        string `json:"version,omitempty"`
-	InformationURI string `json:"informationUri,omitempty"`
+	Name           string                `json:"name"`
+	Version        string                `json:"version,omitempty"`
+	InformationURI string                `json:"informationUri,omitempty"`
+	Rules          []ReportingDescriptor `json:"rules,omitempty"`
+	Extra          Extra                 `json:"-"`
+}
+
+// ReportingDescriptor describes a rule the tool can report.
+type ReportingDescriptor struct {
+	ID                   string                  `json:"id"`
+	Name                 string                  `json:"name,omitempty"`
+	ShortDescription     *MultiformatMessage     `json:"shortDescription,omitempty"`
+	FullDescription      *MultiformatMessage     `json:"fullDescription,omitempty"`
+	Help                 *MultiformatMessage     `json:"help,omitempty"`
+	HelpURI              string                  `json:"helpUri,omitempty"`
+	DefaultConfiguration *ReportingConfiguration `json:"defaultConfiguration,omitempty"`
+	Extra                Extra                   `json:"-"`
+}
+
+// MultiformatMessage is a message available as plain text and optionally markdown.
+type MultiformatMessage struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown,omitempty"`
+}
+
+// ReportingConfiguration holds a rule's default or overridden settings.
+// A nil Enabled means enabled.
+type ReportingConfiguration struct {
+	Enabled *bool  `json:"enabled,omitempty"`
+	Level   string `json:"level,omitempty"`
 }
 
 // Result is a single finding.
 type Result struct {
 	RuleID    string     `json:"ruleId"`
+	RuleIndex *int       `json:"ruleIndex,omitempty"`
 	Level     string     `json:"level,omitempty"` // error, warning, note
 	Message   Message    `json:"message"`
 	Locations []Location `json:"locations,omitempty"`
+	// RelatedLocations point at other places involved in the finding,
+	// such as the second copy of a duplicate; Locations holds only the
+	// primary one.
+	RelatedLocations []Location `json:"relatedLocations,omitempty"`
+
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	BaselineState       string            `json:"baselineState,omitempty"` // new, unchanged, updated, absent
+	Suppressions        []Suppression     `json:"suppressions,omitempty"`
+	Fixes               []Fix             `json:"fixes,omitempty"`
+	Extra               Extra             `json:"-"`
+
+	// fingerprintSubject is the subject given to WithFingerprint, kept so
+	// the fingerprint can be recomputed once the primary location is made
+	// relative to the source root. It is nil for results never
+	// fingerprinted by lintkit.
+	fingerprintSubject []string
+}
+
+// Suppression records why a result should not be acted upon.
+type Suppression struct {
+	Kind          string `json:"kind"`             // inSource, external
+	Status        string `json:"status,omitempty"` // accepted, underReview, rejected
+	Justification string `json:"justification,omitempty"`
 }
 
-// Message contains the finding's text.
+// Message contains the finding's text. When Arguments are given, Text may
+// hold placeholders such as {0} that stand for them, and "{{" and "}}"
+// stand for literal braces; use Formatted to display it.
 type Message struct {
-	Text string `json:"text"`
+	Text      string   `json:"text"`
+	Arguments []string `json:"arguments,omitempty"`
 }
 
-// Location describes where a result was found.
+// Location describes where a result was found. ID and Message identify
+// and describe related locations.
 type Location struct {
+	ID               int              `json:"id,omitempty"`
 	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+	Message          *Message         `json:"message,omitempty"`
+	Extra            Extra            `json:"-"`
 }
 
 // PhysicalLocation describes a file location.
 type PhysicalLocation struct {
 	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
 	Region           *Region          `json:"region,omitempty"`
+	Extra            Extra            `json:"-"`
 }
 
-// ArtifactLocation describes a file path.
+// ArtifactLocation describes a file path. When URIBaseID is set, URI is
+// relative to the run's original URI base of that name.
 type ArtifactLocation struct {
-	URI string `json:"uri"`
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
 }
 
-// Region describes a span within a file.
+// Region describes a span within a file, either as lines and columns or,
+// for binary content, as a byte range.
 type Region struct {
 	StartLine   int `json:"startLine,omitempty"`
 	StartColumn int `json:"startColumn,omitempty"`
 	EndLine     int `json:"endLine,omitempty"`
 	EndColumn   int `json:"endColumn,omitempty"`
+
+	ByteOffset *int `json:"byteOffset,omitempty"`
+	ByteLength int  `json:"byteLength,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+// Formatted returns the message text with its placeholders replaced by
+// the arguments they name. Placeholders without an argument are kept.
+func (m Message) Formatted() string {
+	if len(m.Arguments) == 0 {
+		return m.Text
+	}
+	var b strings.Builder
+	text := m.Text
+	for len(text) > 0 {
+		switch {
+		case strings.HasPrefix(text, "{{"), strings.HasPrefix(text, "}}"):
+			b.WriteByte(text[0])
+			text = text[2:]
+			continue
+		case text[0] == '{':
+			if end := strings.IndexByte(text, '}'); end > 1 {
+				if n, err := strconv.Atoi(text[1:end]); err == nil && n >= 0 && n < len(m.Arguments) {
+					b.WriteString(m.Arguments[n])
+					text = text[end+1:]
+					continue
+				}
+			}
+		}
+		b.WriteByte(text[0])
+		text = text[1:]
+	}
+	return b.String()
+}
+
+// Related returns a related location for path, numbered id, with an
+// optional line and description.
+func Related(id int, path string, line int, text string) Location {
+	loc := Location{ID: id, PhysicalLocation: PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: filepath.ToSlash(path)}}}
+	if line > 0 {
+		loc.PhysicalLocation.Region = &Region{StartLine: line}
+	}
+	if text != "" {
+		loc.Message = &Message{Text: text}
+	}
+	return loc
 }
 
 // NewLog creates a new SARIF log with default values.
@@ -80,6 +204,127 @@ func NewLog() *Log {
 	}
 }
 
+// Text returns a plain-text multiformat message.
+func Text(text string) *MultiformatMessage {
+	return &MultiformatMessage{Text: text}
+}
+
+// Rule looks up a rule descriptor by ID, returning its index in the driver's
+// rule catalog or -1 when the driver does not describe it.
+func (d Driver) Rule(id string) (ReportingDescriptor, int) {
+	for i, r := range d.Rules {
+		if r.ID == id {
+			return r, i
+		}
+	}
+	return ReportingDescriptor{}, -1
+}
+
+// NewRun creates a run for the driver and links each result to its rule
+// descriptor via ruleIndex.
+func NewRun(driver Driver, results []Result) Run {
+	run := Run{Tool: Tool{Driver: driver}, Results: results}
+	run.IndexRules()
+	return run
+}
+
+// IndexRules sets ruleIndex on every result whose rule is described by the
+// run's driver.
+func (r *Run) IndexRules() {
+	for i := range r.Results {
+		_, idx := r.Tool.Driver.Rule(r.Results[i].RuleID)
+		if idx < 0 {
+			r.Results[i].RuleIndex = nil
+			continue
+		}
+		r.Results[i].RuleIndex = &idx
+	}
+}
+
+// Fingerprint hashes a rule ID, artifact URI, and normalized subject into a
+// stable identifier. Line numbers and message wording are deliberately left
+// out so findings can be matched across runs after unrelated edits.
+func Fingerprint(ruleID, uri string, subject ...string) string {
+	h := sha256.New()
+	parts := append([]string{ruleID, filepath.ToSlash(uri)}, subject...)
+	_, _ = io.WriteString(h, strings.Join(parts, "\x00"))
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+// IsSuppressed reports whether the result carries an accepted (or
+// status-less) suppression.
+func (r Result) IsSuppressed() bool {
+	for _, s := range r.Suppressions {
+		if s.Status == "" || s.Status == "accepted" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive reports whether the result still needs attention: it is neither
+// suppressed nor a baseline entry that has since disappeared.
+func (r Result) IsActive() bool {
+	return !r.IsSuppressed() && r.BaselineState != "absent"
+}
+
+// LevelRank orders SARIF levels by severity: "error" ranks highest, then
+// "warning", "note", and "none". An empty level ranks as "warning", the
+// SARIF default.
+func LevelRank(level string) int {
+	switch level {
+	case "error":
+		return 3
+	case "warning", "":
+		return 2
+	case "note":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// PrimaryURI returns the artifact URI of the result's first location, or ""
+// when it has none.
+func (r Result) PrimaryURI() string {
+	if len(r.Locations) == 0 {
+		return ""
+	}
+	return r.Locations[0].PhysicalLocation.ArtifactLocation.URI
+}
+
+// StableFingerprint returns the result's lintkit fingerprint, deriving one
+// from its rule, primary artifact, and message when the producing tool did
+// not set it, as is the case for SARIF from other tools.
+func (r Result) StableFingerprint() string {
+	if fp := r.PartialFingerprints[FingerprintKey]; fp != "" {
+		return fp
+	}
+	return Fingerprint(r.RuleID, r.PrimaryURI(), r.Message.Formatted())
+}
+
+// WithFingerprint returns a copy of r whose partialFingerprints include a
+// lintkit fingerprint over its rule, primary artifact, and the given subject.
+// Run.Relativize and Writer recompute it over the artifact's path relative
+// to the source root, so it does not depend on how the linted paths were
+// spelled on the command line.
+func (r Result) WithFingerprint(subject ...string) Result {
+	r.fingerprintSubject = append([]string{}, subject...)
+	r.refingerprint()
+	return r
+}
+
+// refingerprint sets the lintkit fingerprint of a result fingerprinted by
+// WithFingerprint from its current primary artifact.
+func (r *Result) refingerprint() {
+	fps := make(map[string]string, len(r.PartialFingerprints)+1)
+	for k, v := range r.PartialFingerprints {
+		fps[k] = v
+	}
+	fps[FingerprintKey] = Fingerprint(r.RuleID, r.PrimaryURI(), r.fingerprintSubject...)
+	r.PartialFingerprints = fps
+}
+
 // Encoder wraps a JSON encoder with SARIF-friendly defaults.
 type Encoder struct {
 	enc *json.Encoder
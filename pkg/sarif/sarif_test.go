@@ -131,3 +131,101 @@ func TestEncoder_HandlesEncodingScenarios_When_WritingLogs(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRun_IndexesResultsAgainstDriverRules(t *testing.T) {
+	t.Parallel()
+
+	driver := sarif.Driver{
+		Name: "lintkit",
+		Rules: []sarif.ReportingDescriptor{
+			{ID: "first", DefaultConfiguration: &sarif.ReportingConfiguration{Level: "error"}},
+			{ID: "second", ShortDescription: sarif.Text("second rule")},
+		},
+	}
+
+	run := sarif.NewRun(driver, []sarif.Result{
+		{RuleID: "second"},
+		{RuleID: "first"},
+		{RuleID: "unknown"},
+	})
+
+	if got := run.Results[0].RuleIndex; got == nil || *got != 1 {
+		t.Fatalf("expected ruleIndex 1 for second, got %v", got)
+	}
+	if got := run.Results[1].RuleIndex; got == nil || *got != 0 {
+		t.Fatalf("expected ruleIndex 0 for first, got %v", got)
+	}
+	if run.Results[2].RuleIndex != nil {
+		t.Fatalf("expected no ruleIndex for unknown rule, got %d", *run.Results[2].RuleIndex)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := sarif.NewEncoder(buf).Encode(&sarif.Log{Version: sarif.Version, Runs: []sarif.Run{run}}); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "\"ruleIndex\": 0") {
+		t.Fatalf("expected zero ruleIndex to be emitted, got %s", output)
+	}
+	if !strings.Contains(output, "\"shortDescription\": {\n") {
+		t.Fatalf("expected shortDescription in driver rules, got %s", output)
+	}
+}
+
+func TestWithFingerprint_IgnoresLineAndMessage(t *testing.T) {
+	t.Parallel()
+
+	base := sarif.Result{
+		RuleID:  "RL001",
+		Message: sarif.Message{Text: "first wording"},
+		Locations: []sarif.Location{{
+			PhysicalLocation: sarif.PhysicalLocation{
+				ArtifactLocation: sarif.ArtifactLocation{URI: "docs/a.md"},
+				Region:           &sarif.Region{StartLine: 3},
+			},
+		}},
+	}
+	moved := base
+	moved.Message = sarif.Message{Text: "second wording"}
+	moved.Locations = []sarif.Location{{
+		PhysicalLocation: sarif.PhysicalLocation{
+			ArtifactLocation: sarif.ArtifactLocation{URI: "docs/a.md"},
+			Region:           &sarif.Region{StartLine: 40},
+		},
+	}}
+
+	a := base.WithFingerprint("subject")
+	b := moved.WithFingerprint("subject")
+	c := base.WithFingerprint("other")
+
+	if a.PartialFingerprints[sarif.FingerprintKey] == "" {
+		t.Fatalf("expected fingerprint to be set")
+	}
+	if a.PartialFingerprints[sarif.FingerprintKey] != b.PartialFingerprints[sarif.FingerprintKey] {
+		t.Fatalf("expected fingerprint to survive line and message changes")
+	}
+	if a.PartialFingerprints[sarif.FingerprintKey] == c.PartialFingerprints[sarif.FingerprintKey] {
+		t.Fatalf("expected different subjects to produce different fingerprints")
+	}
+	if base.PartialFingerprints != nil {
+		t.Fatalf("WithFingerprint must not mutate the receiver")
+	}
+}
+
+func TestMessageFormatted_FillsPlaceholders(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		msg  sarif.Message
+		want string
+	}{
+		{sarif.Message{Text: "plain {0}"}, "plain {0}"},
+		{sarif.Message{Text: "{0} is older than {1}", Arguments: []string{"a.bin", "a.txt"}}, "a.bin is older than a.txt"},
+		{sarif.Message{Text: "{{literal}} {0} {2}", Arguments: []string{"x"}}, "{literal} x {2}"},
+	}
+	for _, c := range cases {
+		if got := c.msg.Formatted(); got != c.want {
+			t.Fatalf("Formatted(%q) = %q, want %q", c.msg.Text, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package sarif
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Writer streams a SARIF log one result at a time, so a run with many
+// results never has to be held in memory. Its output matches Encoder's:
+//
+//	w := sarif.NewWriter(out)
+//	w.BeginRun(sarif.Run{Tool: sarif.Tool{Driver: driver}})
+//	for _, r := range results {
+//		w.WriteResult(r)
+//	}
+//	w.EndRun()
+//	w.Close()
+//
+// The first error is sticky: later calls return it without writing.
+type Writer struct {
+	w   *bufio.Writer
+	err error
+
+	started bool // log header written
+	runs    int
+	inRun   bool
+	run     Run // current run, without results
+	rel     relativizer
+	hasRel  bool
+	results int
+}
+
+// Indentation of the streamed JSON, matching Encoder.
+const (
+	runIndent    = "    "
+	resultIndent = "        "
+)
+
+// NewWriter returns a Writer that writes to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// BeginRun starts a run described by run. Its results, if any, are written
+// first; further results follow with WriteResult. Invocations may instead
+// be passed to EndRun, for runs whose invocation ends after their last
+// result.
+func (s *Writer) BeginRun(run Run) error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.inRun {
+		return s.fail(errors.New("sarif: BeginRun inside a run"))
+	}
+	if !s.started {
+		s.started = true
+		s.printf("{\n  \"version\": %s,\n  \"$schema\": %s,\n  \"runs\": [", quote(Version), quote(NewLog().Schema))
+	}
+	if s.runs > 0 {
+		s.printf(",")
+	}
+	s.runs++
+
+	results := run.Results
+	run.Results = nil
+	head, err := json.MarshalIndent(run, runIndent, "  ")
+	if err != nil {
+		return s.fail(err)
+	}
+	head = bytes.TrimSuffix(head, []byte("\n"+runIndent+"}"))
+	s.printf("\n%s%s", runIndent, head)
+
+	s.inRun, s.run, s.results = true, run, 0
+	s.rel, s.hasRel = s.run.srcRootRelativizer()
+	for _, r := range results {
+		if err := s.WriteResult(r); err != nil {
+			return err
+		}
+	}
+	return s.err
+}
+
+// WriteResult writes a result of the current run, linking it to its rule
+// descriptor via ruleIndex and, when the run has a SrcRoot base, making
+// its artifact locations relative to it as Run.Relativize does.
+func (s *Writer) WriteResult(r Result) error {
+	if s.err != nil {
+		return s.err
+	}
+	if !s.inRun {
+		return s.fail(errors.New("sarif: WriteResult outside a run"))
+	}
+	if _, idx := s.run.Tool.Driver.Rule(r.RuleID); idx >= 0 {
+		r.RuleIndex = &idx
+	} else {
+		r.RuleIndex = nil
+	}
+	if s.hasRel {
+		s.rel.result(&r)
+	}
+	data, err := json.MarshalIndent(r, resultIndent, "  ")
+	if err != nil {
+		return s.fail(err)
+	}
+	if s.results == 0 {
+		s.printf(",\n%s  \"results\": [", runIndent)
+	} else {
+		s.printf(",")
+	}
+	s.results++
+	s.printf("\n%s%s", resultIndent, data)
+	return s.err
+}
+
+// EndRun finishes the current run. Invocations given here are written
+// after its results; the run passed to BeginRun must then have none.
+func (s *Writer) EndRun(invocations ...Invocation) error {
+	if s.err != nil {
+		return s.err
+	}
+	if !s.inRun {
+		return s.fail(errors.New("sarif: EndRun outside a run"))
+	}
+	if len(invocations) > 0 && len(s.run.Invocations) > 0 {
+		return s.fail(errors.New("sarif: run invocations given twice"))
+	}
+	if s.results > 0 {
+		s.printf("\n%s  ]", runIndent)
+	}
+	if len(invocations) > 0 {
+		data, err := json.MarshalIndent(invocations, runIndent+"  ", "  ")
+		if err != nil {
+			return s.fail(err)
+		}
+		s.printf(",\n%s  \"invocations\": %s", runIndent, data)
+	}
+	s.printf("\n%s}", runIndent)
+	s.inRun, s.run = false, Run{}
+	return s.err
+}
+
+// Close finishes the log, ending any open run, and flushes it. A Writer
+// with no runs writes an empty log.
+func (s *Writer) Close() error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.inRun {
+		if err := s.EndRun(); err != nil {
+			return err
+		}
+	}
+	if !s.started {
+		s.started = true
+		return s.fail(NewEncoder(s.w).Encode(NewLog()), s.w.Flush())
+	}
+	if s.runs > 0 {
+		s.printf("\n  ")
+	}
+	s.printf("]\n}\n")
+	if s.err == nil {
+		s.err = s.w.Flush()
+	}
+	return s.err
+}
+
+func (s *Writer) printf(format string, args ...any) {
+	if s.err != nil {
+		return
+	}
+	if len(args) == 0 {
+		_, s.err = s.w.WriteString(format)
+		return
+	}
+	_, s.err = fmt.Fprintf(s.w, format, args...)
+}
+
+// fail records the first non-nil error and returns it.
+func (s *Writer) fail(errs ...error) error {
+	if s.err == nil {
+		s.err = errors.Join(errs...)
+	}
+	return s.err
+}
+
+func quote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,226 @@
+package sarif
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SrcRoot is the uriBaseId of artifact locations relative to the root of
+// the analyzed source tree, as GitHub code scanning expects.
+const SrcRoot = "%SRCROOT%"
+
+// FileURI returns the file:// URI of path, made absolute against the
+// working directory.
+func FileURI(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	slashed := filepath.ToSlash(abs)
+	if !strings.HasPrefix(slashed, "/") {
+		slashed = "/" + slashed // Windows drive letters
+	}
+	return (&url.URL{Scheme: "file", Path: slashed}).String()
+}
+
+// filePath returns the local path named by a file:// URI.
+func filePath(uri string) (string, bool) {
+	if !strings.HasPrefix(uri, "file://") {
+		return "", false
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return filepath.FromSlash(strings.TrimPrefix(uri, "file://")), true
+	}
+	path := u.Path
+	if len(path) > 2 && path[0] == '/' && path[2] == ':' {
+		path = path[1:] // Windows drive letters
+	}
+	return filepath.FromSlash(path), true
+}
+
+// Rebase joins dir onto the relative artifact locations of results, for
+// analyzers that report paths relative to the directory they analyzed
+// rather than to the working directory. It returns results.
+func Rebase(results []Result, dir string) []Result {
+	for i := range results {
+		for j := range results[i].Locations {
+			rebase(&results[i].Locations[j].PhysicalLocation.ArtifactLocation, dir)
+		}
+		for j := range results[i].RelatedLocations {
+			rebase(&results[i].RelatedLocations[j].PhysicalLocation.ArtifactLocation, dir)
+		}
+	}
+	return results
+}
+
+func rebase(loc *ArtifactLocation, dir string) {
+	if loc.URI == "" || loc.URIBaseID != "" || strings.Contains(loc.URI, "://") || filepath.IsAbs(loc.URI) {
+		return
+	}
+	loc.URI = filepath.Join(dir, filepath.FromSlash(loc.URI))
+}
+
+// Relativize rewrites the artifact locations of every run in log relative
+// to root; see Run.Relativize.
+func Relativize(log *Log, root string) error {
+	for i := range log.Runs {
+		if err := log.Runs[i].Relativize(root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Relativize rewrites the artifact locations of the run's results and
+// fixes as slash-separated paths relative to root under SrcRoot, and
+// records root in originalUriBaseIds. Relative paths are taken to be
+// relative to the working directory. Directories get a trailing slash;
+// paths outside root become absolute file:// URIs. Locations that already
+// have a base are left alone. Fingerprints set by Result.WithFingerprint
+// are recomputed over the relative paths.
+func (r *Run) Relativize(root string) error {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+	real, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		real = abs
+	}
+	rootURI := FileURI(abs)
+	if !strings.HasSuffix(rootURI, "/") {
+		rootURI += "/"
+	}
+	if r.OriginalURIBaseIDs == nil {
+		r.OriginalURIBaseIDs = map[string]ArtifactLocation{}
+	}
+	r.OriginalURIBaseIDs[SrcRoot] = ArtifactLocation{URI: rootURI}
+
+	rel := relativizer{root: abs, real: real}
+	for i := range r.Results {
+		rel.result(&r.Results[i])
+	}
+	return nil
+}
+
+// relativizer rewrites artifact locations relative to root, whose
+// symlink-free form is real.
+type relativizer struct {
+	root, real string
+}
+
+// srcRootRelativizer returns a relativizer for the run's SrcRoot base, if
+// it has one.
+func (r *Run) srcRootRelativizer() (relativizer, bool) {
+	base, ok := r.OriginalURIBaseIDs[SrcRoot]
+	if !ok {
+		return relativizer{}, false
+	}
+	root, ok := filePath(base.URI)
+	if !ok {
+		return relativizer{}, false
+	}
+	root = filepath.Clean(root)
+	real, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		real = root
+	}
+	return relativizer{root: root, real: real}, true
+}
+
+func (rel relativizer) result(res *Result) {
+	for j := range res.Locations {
+		relativize(&res.Locations[j].PhysicalLocation.ArtifactLocation, rel.root, rel.real)
+	}
+	for j := range res.RelatedLocations {
+		relativize(&res.RelatedLocations[j].PhysicalLocation.ArtifactLocation, rel.root, rel.real)
+	}
+	for j := range res.Fixes {
+		for k := range res.Fixes[j].ArtifactChanges {
+			relativize(&res.Fixes[j].ArtifactChanges[k].ArtifactLocation, rel.root, rel.real)
+		}
+	}
+	if res.fingerprintSubject != nil {
+		res.refingerprint()
+	}
+}
+
+// relativize rewrites loc relative to root, whose symlink-free form is
+// real.
+func relativize(loc *ArtifactLocation, root, real string) {
+	if loc.URI == "" || loc.URIBaseID != "" {
+		return
+	}
+	path, ok := filePath(loc.URI)
+	if !ok {
+		if strings.Contains(loc.URI, "://") {
+			return // not a file
+		}
+		path = filepath.FromSlash(loc.URI)
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return
+	}
+
+	rel, inside := within(root, path)
+	if !inside {
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			rel, inside = within(real, resolved)
+		}
+	}
+	if !inside {
+		loc.URI = FileURI(path)
+		return
+	}
+
+	uri := filepath.ToSlash(rel)
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		if uri == "." {
+			uri = "./"
+		} else {
+			uri += "/"
+		}
+	}
+	loc.URI = uri
+	loc.URIBaseID = SrcRoot
+}
+
+// within returns path relative to root and whether it lies inside root.
+func within(root, path string) (string, bool) {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// LocalPath returns the file system path of an artifact location,
+// resolving its uriBaseId against the run's originalUriBaseIds. Locations
+// without a known base are returned as paths relative to the working
+// directory.
+func (r *Run) LocalPath(loc ArtifactLocation) string {
+	if loc.URIBaseID != "" {
+		if base, ok := r.OriginalURIBaseIDs[loc.URIBaseID]; ok {
+			if dir, ok := filePath(base.URI); ok {
+				return filepath.Join(dir, filepath.FromSlash(loc.URI))
+			}
+		}
+	}
+	if path, ok := filePath(loc.URI); ok {
+		return path
+	}
+	return filepath.FromSlash(loc.URI)
+}
+
+// PrimaryPath returns the file system path of the result's first location,
+// or "" when it has none.
+func (r *Run) PrimaryPath(res Result) string {
+	if len(res.Locations) == 0 {
+		return ""
+	}
+	return r.LocalPath(res.Locations[0].PhysicalLocation.ArtifactLocation)
+}
@@ -0,0 +1,21 @@
+package stale
+
+import "github.com/dkoosis/lintkit/pkg/sarif"
+
+// ToolName is the SARIF driver name for stale.
+const ToolName = "lintkit-stale"
+
+var rules = []sarif.ReportingDescriptor{
+	{
+		ID:                   ruleID,
+		ShortDescription:     sarif.Text("Derived artifact is older than its source"),
+		FullDescription:      sarif.Text("A file matching a rule's derived pattern has an older modification time than a file matching its source pattern, so it was probably not regenerated."),
+		Help:                 sarif.Text("Regenerate the derived artifact from its source."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: defaultLevel},
+	},
+}
+
+// Rules returns the rule catalog for stale.
+func Rules() []sarif.ReportingDescriptor {
+	return append([]sarif.ReportingDescriptor(nil), rules...)
+}
@@ -2,14 +2,13 @@
 package stale
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/yamlconf"
 )
 
 const (
@@ -39,107 +38,35 @@ type Config struct {
 	Rules []Rule `yaml:"rules"`
 }
 
-// LoadConfig reads a YAML configuration file from disk.
+// LoadConfig reads a YAML configuration file from disk. Unknown keys and
+// invalid rules are reported with their position in the file.
 func LoadConfig(path string) (Config, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
+	var cfg Config
+	if err := yamlconf.Load(path, &cfg); err != nil {
 		return Config{}, err
 	}
-
-	cfg, err := parseConfig(data)
-	if err != nil {
-		return Config{}, err
+	if err := cfg.Validate(); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
 	}
-
 	return cfg, nil
 }
 
-func parseConfig(data []byte) (Config, error) {
-	rules, err := parseRulesYAML(string(data))
-	if err != nil {
-		return Config{}, err
-	}
-
-	cfg := Config{Rules: rules}
-	for i := range cfg.Rules {
-		if cfg.Rules[i].Mode == "" {
-			cfg.Rules[i].Mode = ModeMTime
+// Validate checks that every rule names its derived and source patterns
+// and a supported mode, defaulting the mode to ModeMTime.
+func (c *Config) Validate() error {
+	for i := range c.Rules {
+		r := &c.Rules[i]
+		switch {
+		case r.Derived == "":
+			return fmt.Errorf("rule %d: derived is required", i)
+		case r.Source == "":
+			return fmt.Errorf("rule %d: source is required", i)
+		case r.Mode == "":
+			r.Mode = ModeMTime
+		case r.Mode != ModeMTime:
+			return fmt.Errorf("rule %d: unsupported mode %q (want %s)", i, r.Mode, ModeMTime)
 		}
 	}
-
-	return cfg, nil
-}
-
-func parseRulesYAML(content string) ([]Rule, error) {
-	scanner := bufio.NewScanner(strings.NewReader(content))
-	var rules []Rule
-	var current *Rule
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		trimmed := strings.TrimSpace(line)
-
-		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
-			continue
-		}
-
-		if trimmed == "rules:" {
-			continue
-		}
-
-		if strings.HasPrefix(trimmed, "-") {
-			if current != nil {
-				rules = append(rules, *current)
-			}
-
-			current = &Rule{}
-			trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
-			if trimmed == "" {
-				continue
-			}
-			if err := populateRuleField(current, trimmed); err != nil {
-				return nil, err
-			}
-			continue
-		}
-
-		if current != nil {
-			if err := populateRuleField(current, trimmed); err != nil {
-				return nil, err
-			}
-		}
-	}
-
-	if current != nil {
-		rules = append(rules, *current)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
-	}
-
-	return rules, nil
-}
-
-func populateRuleField(rule *Rule, line string) error {
-	parts := strings.SplitN(line, ":", 2)
-	if len(parts) != 2 {
-		return fmt.Errorf("invalid rule line: %s", line)
-	}
-
-	key := strings.TrimSpace(parts[0])
-	val := strings.TrimSpace(parts[1])
-	val = strings.Trim(val, "\"")
-
-	switch key {
-	case "derived":
-		rule.Derived = val
-	case "source":
-		rule.Source = val
-	case "mode":
-		rule.Mode = RuleMode(val)
-	}
-
 	return nil
 }
 
@@ -233,13 +160,12 @@ func buildResult(root, derived, source string) sarif.Result {
 		sourceRel = source
 	}
 
-	message := fmt.Sprintf("derived file %s is older than source %s", derivedRel, sourceRel)
-
-	return sarif.Result{
+	res := sarif.Result{
 		RuleID: ruleID,
 		Level:  defaultLevel,
 		Message: sarif.Message{
-			Text: message,
+			Text:      "derived file {0} is older than source {1}",
+			Arguments: []string{filepath.ToSlash(derivedRel), filepath.ToSlash(sourceRel)},
 		},
 		Locations: []sarif.Location{
 			{
@@ -248,5 +174,7 @@ func buildResult(root, derived, source string) sarif.Result {
 				},
 			},
 		},
+		RelatedLocations: []sarif.Location{sarif.Related(1, sourceRel, 0, "newer source file")},
 	}
+	return res.WithFingerprint(filepath.ToSlash(sourceRel))
 }
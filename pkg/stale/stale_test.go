@@ -3,6 +3,7 @@ package stale
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -32,6 +33,18 @@ rules:
 	}
 }
 
+func TestLoadConfigRejectsUnknownKeys(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "rules.yml")
+	if err := os.WriteFile(tmpFile, []byte("rules:\n  - derived: go.sum\n    sorce: go.mod\n"), 0o644); err != nil {
+		t.Fatalf("write temp rules: %v", err)
+	}
+
+	_, err := LoadConfig(tmpFile)
+	if err == nil || !strings.Contains(err.Error(), tmpFile+`:3:5: unknown key "sorce"`) {
+		t.Fatalf("expected positioned unknown key error, got %v", err)
+	}
+}
+
 func TestEvaluateMTime(t *testing.T) {
 	dir := t.TempDir()
 	cfg := Config{
@@ -75,6 +88,14 @@ func TestEvaluateMTime(t *testing.T) {
 	if results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI != "output.bin" {
 		t.Errorf("expected derived relative path, got %s", results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
 	}
+
+	related := results[0].RelatedLocations
+	if len(related) != 1 || related[0].ID != 1 || related[0].PhysicalLocation.ArtifactLocation.URI != "schema.txt" {
+		t.Errorf("expected source as related location, got %+v", related)
+	}
+	if got := results[0].Message.Formatted(); got != "derived file output.bin is older than source schema.txt" {
+		t.Errorf("unexpected message: %s", got)
+	}
 }
 
 func TestEvaluateNotStale(t *testing.T) {
@@ -0,0 +1,256 @@
+// Package suppress honours inline suppression directives in source files
+// and records them on SARIF results.
+//
+// Markdown files accept HTML comments:
+//
+//	<!-- lintkit-disable-next-line wiki-tag-orphan one-off tag -->
+//	<!-- lintkit-disable-file doc-orphan,doc-readme-too-large -->
+//
+// Inside YAML frontmatter, where HTML comments are not allowed, the same
+// directives are written as YAML comments:
+//
+//	# lintkit-disable-next-line wiki-tag-orphan one-off tag
+//
+// Go files accept line comments:
+//
+//	//lintkit:ignore filesize-budget generated table
+//	package tables
+//
+// A Go directive on its own line applies to the next line, a trailing one
+// to its own line, and one above the package clause to the whole file.
+// Results without a line region, such as filesize-budget and
+// stale-artifact, are matched only by the whole-file form.
+// Each directive names one or more comma-separated rule IDs ("*" matches
+// every rule); any remaining text is recorded as the justification.
+package suppress
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// Kind is the SARIF suppression kind recorded for inline directives.
+const Kind = "inSource"
+
+// Directive is a parsed suppression comment.
+type Directive struct {
+	// Rules lists the suppressed rule IDs; "*" matches every rule.
+	Rules []string
+	// Line is the line the directive applies to; 0 means the whole file.
+	Line int
+	// Reason is the free-text justification, possibly empty.
+	Reason string
+	// Source is the directive keyword, used in the default justification.
+	Source string
+}
+
+// Matches reports whether the directive covers rule at line. A file-wide
+// directive covers every line, including results without one.
+func (d Directive) Matches(rule string, line int) bool {
+	if d.Line != 0 && d.Line != line {
+		return false
+	}
+	for _, r := range d.Rules {
+		if r == "*" || r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// Justification returns the reason, or a description of the directive
+// when none was given.
+func (d Directive) Justification() string {
+	if d.Reason != "" {
+		return d.Reason
+	}
+	return "suppressed by " + d.Source + " directive"
+}
+
+var (
+	markdownDirective    = regexp.MustCompile(`<!--\s*(lintkit-disable-next-line|lintkit-disable-file)\s+(.*?)\s*-->`)
+	frontmatterDirective = regexp.MustCompile(`^\s*#\s*(lintkit-disable-next-line|lintkit-disable-file)\s+(.*?)\s*$`)
+	goDirective          = regexp.MustCompile(`//lintkit:ignore\s+(.*)$`)
+	goPackage            = regexp.MustCompile(`^package\s+\w+`)
+)
+
+// Parse returns the directives in lines, interpreting them according to
+// the file extension of path. Files of other types have no directives.
+func Parse(path string, lines []string) []Directive {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return parseMarkdown(lines)
+	case ".go":
+		return parseGo(lines)
+	default:
+		return nil
+	}
+}
+
+func parseMarkdown(lines []string) []Directive {
+	var out []Directive
+	inFrontmatter := len(lines) > 0 && strings.TrimSpace(lines[0]) == "---"
+	for i, line := range lines {
+		matches := markdownDirective.FindAllStringSubmatch(line, -1)
+		if inFrontmatter && i > 0 {
+			if strings.TrimSpace(line) == "---" {
+				inFrontmatter = false
+			} else if m := frontmatterDirective.FindStringSubmatch(line); m != nil {
+				matches = append(matches, m)
+			}
+		}
+		for _, m := range matches {
+			d := newDirective(m[1], m[2])
+			if m[1] == "lintkit-disable-next-line" {
+				d.Line = i + 2
+			}
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+func parseGo(lines []string) []Directive {
+	var out []Directive
+	seenPackage := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if goPackage.MatchString(trimmed) {
+			seenPackage = true
+		}
+		m := goDirective.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		d := newDirective("lintkit:ignore", m[1])
+		switch {
+		case !strings.HasPrefix(trimmed, "//"):
+			d.Line = i + 1
+		case seenPackage:
+			d.Line = i + 2
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// newDirective splits "rule[,rule] reason..." into a directive.
+func newDirective(source, body string) Directive {
+	fields := strings.Fields(body)
+	d := Directive{Source: source}
+	if len(fields) == 0 {
+		return d
+	}
+	for _, r := range strings.Split(fields[0], ",") {
+		if r != "" {
+			d.Rules = append(d.Rules, r)
+		}
+	}
+	d.Reason = strings.TrimSpace(strings.TrimPrefix(strings.Join(fields[1:], " "), "--"))
+	return d
+}
+
+// Apply adds an inSource suppression to every result covered by a
+// directive in its primary artifact. URIs with a uriBaseId are resolved
+// against the run's originalUriBaseIds and other relative URIs against
+// root. Files are read at most once; unreadable files are skipped.
+func Apply(log *sarif.Log, root string) {
+	s := New(root)
+	for i := range log.Runs {
+		run := &log.Runs[i]
+		for j := range run.Results {
+			s.Result(run, &run.Results[j])
+		}
+	}
+}
+
+// Suppressor applies directives result by result, for results that are
+// streamed rather than collected in a log. It caches the directives of
+// every file it reads.
+type Suppressor struct {
+	root  string
+	cache map[string][]Directive
+}
+
+// New returns a Suppressor resolving relative URIs against root, as Apply
+// does.
+func New(root string) *Suppressor {
+	return &Suppressor{root: root, cache: map[string][]Directive{}}
+}
+
+// Result adds an inSource suppression to r, a result of run, when a
+// directive in its primary artifact covers it.
+func (s *Suppressor) Result(run *sarif.Run, r *sarif.Result) {
+	path := run.PrimaryPath(*r)
+	if path == "" || hasInSource(*r) {
+		return
+	}
+	directives, ok := s.cache[path]
+	if !ok {
+		directives = load(resolve(s.root, path))
+		s.cache[path] = directives
+	}
+	line := 0
+	if region := r.Locations[0].PhysicalLocation.Region; region != nil {
+		line = region.StartLine
+	}
+	for _, d := range directives {
+		if d.Matches(r.RuleID, line) {
+			r.Suppressions = append(r.Suppressions, sarif.Suppression{
+				Kind:          Kind,
+				Status:        "accepted",
+				Justification: d.Justification(),
+			})
+			return
+		}
+	}
+}
+
+func hasInSource(r sarif.Result) bool {
+	for _, s := range r.Suppressions {
+		if s.Kind == Kind {
+			return true
+		}
+	}
+	return false
+}
+
+func resolve(root, path string) string {
+	if filepath.IsAbs(path) || root == "" {
+		return path
+	}
+	return filepath.Join(root, path)
+}
+
+func load(path string) []Directive {
+	if !supported(path) {
+		return nil
+	}
+	f, err := os.Open(path) //nolint:gosec // G304: path comes from SARIF results
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = f.Close() }()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return Parse(path, lines)
+}
+
+func supported(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown", ".go":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,124 @@
+package suppress
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	lines := []string{
+		"<!-- lintkit-disable-file doc-orphan,doc-duplicate -->",
+		"# Title",
+		"<!-- lintkit-disable-next-line wiki-tag-orphan one-off tag -->",
+		"tags: [Once]",
+	}
+	got := Parse("docs/a.md", lines)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 directives, got %+v", got)
+	}
+	if !got[0].Matches("doc-duplicate", 0) || !got[0].Matches("doc-duplicate", 9) {
+		t.Fatalf("expected file directive to match everywhere: %+v", got[0])
+	}
+	if !got[1].Matches("wiki-tag-orphan", 4) || got[1].Matches("wiki-tag-orphan", 3) {
+		t.Fatalf("expected next-line directive to match line 4 only: %+v", got[1])
+	}
+	if got[1].Justification() != "one-off tag" {
+		t.Fatalf("unexpected justification %q", got[1].Justification())
+	}
+}
+
+func TestParseMarkdownFrontmatter(t *testing.T) {
+	lines := []string{
+		"---",
+		"title: Page",
+		"# lintkit-disable-next-line wiki-tag-orphan one-off",
+		"tags: [Once]",
+		"---",
+		"# lintkit-disable-next-line ignored-outside-frontmatter",
+	}
+	got := Parse("a.md", lines)
+	if len(got) != 1 || !got[0].Matches("wiki-tag-orphan", 4) {
+		t.Fatalf("expected one frontmatter directive for line 4, got %+v", got)
+	}
+}
+
+func TestParseGo(t *testing.T) {
+	lines := []string{
+		"//lintkit:ignore filesize-budget generated file",
+		"package demo",
+		"",
+		"//lintkit:ignore rule-a -- intentional",
+		"var a = 1",
+		"var b = 2 //lintkit:ignore rule-b",
+	}
+	got := Parse("demo.go", lines)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 directives, got %+v", got)
+	}
+	if got[0].Line != 0 || got[1].Line != 5 || got[2].Line != 6 {
+		t.Fatalf("unexpected lines: %d %d %d", got[0].Line, got[1].Line, got[2].Line)
+	}
+	if got[1].Reason != "intentional" {
+		t.Fatalf("unexpected reason %q", got[1].Reason)
+	}
+	if got[2].Justification() != "suppressed by lintkit:ignore directive" {
+		t.Fatalf("unexpected default justification %q", got[2].Justification())
+	}
+}
+
+func TestApply(t *testing.T) {
+	root := t.TempDir()
+	doc := "# Doc\n<!-- lintkit-disable-next-line wiki-missing-title -->\nbody\n"
+	if err := os.WriteFile(filepath.Join(root, "a.md"), []byte(doc), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	at := func(rule string, line int) sarif.Result {
+		return sarif.Result{RuleID: rule, Locations: []sarif.Location{{PhysicalLocation: sarif.PhysicalLocation{
+			ArtifactLocation: sarif.ArtifactLocation{URI: "a.md"},
+			Region:           &sarif.Region{StartLine: line},
+		}}}}
+	}
+	log := sarif.NewLog()
+	log.Runs = append(log.Runs, sarif.Run{Results: []sarif.Result{at("wiki-missing-title", 3), at("wiki-missing-title", 1), at("other", 3)}})
+
+	Apply(log, root)
+	Apply(log, root)
+
+	results := log.Runs[0].Results
+	if len(results[0].Suppressions) != 1 || results[0].Suppressions[0].Kind != Kind {
+		t.Fatalf("expected one inSource suppression, got %+v", results[0].Suppressions)
+	}
+	if results[1].IsSuppressed() || results[2].IsSuppressed() {
+		t.Fatalf("expected other results to stay active")
+	}
+}
+
+func TestApplyFileDirectiveToResultsWithoutRegion(t *testing.T) {
+	root := t.TempDir()
+	src := "//lintkit:ignore filesize-budget generated table\npackage tables\n\n//lintkit:ignore stale-artifact\nvar t = 1\n"
+	if err := os.WriteFile(filepath.Join(root, "t.go"), []byte(src), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	at := func(rule string) sarif.Result {
+		return sarif.Result{RuleID: rule, Locations: []sarif.Location{{PhysicalLocation: sarif.PhysicalLocation{
+			ArtifactLocation: sarif.ArtifactLocation{URI: "t.go"},
+		}}}}
+	}
+	log := sarif.NewLog()
+	log.Runs = append(log.Runs, sarif.Run{Results: []sarif.Result{at("filesize-budget"), at("stale-artifact")}})
+
+	Apply(log, root)
+
+	results := log.Runs[0].Results
+	if !results[0].IsSuppressed() {
+		t.Fatalf("expected the file-wide directive to suppress a result without a region")
+	}
+	if results[1].IsSuppressed() {
+		t.Fatalf("expected a line directive not to match a result without a region")
+	}
+}
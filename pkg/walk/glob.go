@@ -0,0 +1,43 @@
+package walk
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// MatchGlob matches a slash-separated path against a glob pattern where "**"
+// matches zero or more path segments and other segments follow path.Match.
+// Patterns without a slash match against the base name.
+func MatchGlob(pattern, path string) bool {
+	if pattern == "" {
+		return false
+	}
+	pattern = strings.TrimPrefix(filepath.ToSlash(pattern), "./")
+	path = strings.TrimPrefix(filepath.ToSlash(path), "./")
+	if !strings.Contains(pattern, "/") {
+		ok, _ := filepath.Match(pattern, filepath.Base(path))
+		return ok
+	}
+	return matchSegments(strings.Split(strings.TrimSuffix(pattern, "/"), "/"), strings.Split(path, "/"))
+}
+
+func matchSegments(pattern, path []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(path); i++ {
+				if matchSegments(pattern[1:], path[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(path) == 0 {
+			return false
+		}
+		if ok, _ := filepath.Match(pattern[0], path[0]); !ok {
+			return false
+		}
+		pattern, path = pattern[1:], path[1:]
+	}
+	return len(path) == 0
+}
@@ -0,0 +1,114 @@
+package walk
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ignoreRule is one pattern line from a .gitignore or .lintkitignore file.
+type ignoreRule struct {
+	// base is the slash-separated directory of the ignore file, relative
+	// to the walk's top directory; "" is the top itself.
+	base     string
+	segments []string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// ignoreRules holds the rules in effect for a directory, in file order;
+// later rules override earlier ones, as in git.
+type ignoreRules []ignoreRule
+
+// load appends the rules from the ignore files in dir, whose path relative
+// to the top directory is base.
+func (r ignoreRules) load(dir, base string) ignoreRules {
+	for _, name := range ignoreFiles {
+		added := readIgnoreFile(filepath.Join(dir, name), base)
+		if len(added) == 0 {
+			continue
+		}
+		// Copy before appending so sibling directories never share the
+		// backing array.
+		r = append(r[:len(r):len(r)], added...)
+	}
+	return r
+}
+
+// ignored reports whether rel, a slash-separated path relative to the top
+// directory, is ignored.
+func (r ignoreRules) ignored(rel string, isDir bool) bool {
+	ignored := false
+	for _, rule := range r {
+		if rule.matches(rel, isDir) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+func (rule ignoreRule) matches(rel string, isDir bool) bool {
+	if rule.dirOnly && !isDir {
+		return false
+	}
+	sub := rel
+	if rule.base != "" {
+		if !strings.HasPrefix(rel, rule.base+"/") {
+			return false
+		}
+		sub = strings.TrimPrefix(rel, rule.base+"/")
+	}
+	if !rule.anchored {
+		ok, _ := filepath.Match(rule.segments[0], sub[strings.LastIndex(sub, "/")+1:])
+		return ok
+	}
+	return matchSegments(rule.segments, strings.Split(sub, "/"))
+}
+
+func readIgnoreFile(path, base string) []ignoreRule {
+	f, err := os.Open(path) //nolint:gosec // G304: ignore files inside the walked tree
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = f.Close() }()
+
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if rule, ok := parseIgnoreLine(scanner.Text(), base); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// parseIgnoreLine parses one line using gitignore syntax: "#" comments,
+// "!" negation, a trailing "/" for directories only, and patterns with an
+// inner or leading "/" anchored to the ignore file's directory.
+func parseIgnoreLine(line, base string) (ignoreRule, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+	rule := ignoreRule{base: base}
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	}
+	line = strings.TrimPrefix(line, `\`)
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if strings.Contains(line, "/") {
+		rule.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+	if line == "" {
+		return ignoreRule{}, false
+	}
+	rule.segments = strings.Split(line, "/")
+	return rule, true
+}
@@ -0,0 +1,267 @@
+// Package walk traverses file trees the same way for every lintkit linter.
+//
+// A walk skips .git directories, honours .gitignore and .lintkitignore files
+// in the walked directories and their ancestors up to the repository root,
+// drops paths matching the caller's exclude globs, and treats symbolic
+// links according to a Symlinks policy.
+package walk
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// IgnoreFile is lintkit's own ignore file. It uses .gitignore syntax and
+// applies only to lintkit.
+const IgnoreFile = ".lintkitignore"
+
+var ignoreFiles = []string{".gitignore", IgnoreFile}
+
+// Symlinks selects how symbolic links are treated.
+type Symlinks int
+
+const (
+	// SymlinkFiles visits links to files but does not descend into linked
+	// directories. It is the default.
+	SymlinkFiles Symlinks = iota
+	// SymlinkSkip ignores every symbolic link.
+	SymlinkSkip
+	// SymlinkFollow visits links to files and descends into linked
+	// directories, visiting each real directory at most once.
+	SymlinkFollow
+)
+
+// ParseSymlinks parses a policy name: "files", "skip", or "follow". The
+// empty string selects SymlinkFiles.
+func ParseSymlinks(s string) (Symlinks, error) {
+	switch s {
+	case "", "files":
+		return SymlinkFiles, nil
+	case "skip":
+		return SymlinkSkip, nil
+	case "follow":
+		return SymlinkFollow, nil
+	default:
+		return 0, fmt.Errorf("invalid symlink policy %q (want files, skip, or follow)", s)
+	}
+}
+
+// Options controls a walk. The zero value honours ignore files, excludes
+// nothing else, and uses SymlinkFiles.
+type Options struct {
+	// Exclude lists MatchGlob patterns for paths to skip. A matching
+	// directory is not descended into.
+	Exclude []string
+	// ExcludeDirs lists MatchGlob patterns for directories to skip; files
+	// are not matched against them.
+	ExcludeDirs []string
+	// ExcludeBase is the directory Exclude and ExcludeDirs patterns are
+	// relative to. It defaults to the walk root.
+	ExcludeBase string
+	// Symlinks selects the symbolic link policy.
+	Symlinks Symlinks
+	// NoIgnoreFiles disables .gitignore and .lintkitignore handling.
+	NoIgnoreFiles bool
+	// Filter, when non-nil, limits the files passed to the walk function
+	// to those it accepts. Directories are still traversed.
+	Filter func(path string) bool
+}
+
+// Func is called for every file a walk visits. path is the root joined
+// with the file's path below it, as with filepath.WalkDir.
+type Func func(path string, d fs.DirEntry) error
+
+// Walk calls fn for every file under root that is not ignored or excluded,
+// in lexical order. A root that is a file is visited as is. Errors reading
+// directories and errors returned by fn stop the walk.
+func Walk(root string, opts Options, fn Func) error {
+	info, err := os.Stat(root)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		if opts.Filter != nil && !opts.Filter(root) {
+			return nil
+		}
+		return fn(root, fs.FileInfoToDirEntry(info))
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+	w := &walker{opts: opts, fn: fn, absRoot: absRoot, visited: map[string]bool{}}
+	if opts.ExcludeBase != "" {
+		if w.excludeBase, err = filepath.Abs(opts.ExcludeBase); err != nil {
+			return err
+		}
+	} else {
+		w.excludeBase = absRoot
+	}
+
+	var rules ignoreRules
+	top := absRoot
+	if !opts.NoIgnoreFiles {
+		top = repoTop(absRoot)
+		// Ancestors of the root contribute their ignore files first.
+		rel, _ := filepath.Rel(top, absRoot)
+		dir, base := top, ""
+		for _, part := range splitRel(rel) {
+			rules = rules.load(dir, base)
+			dir = filepath.Join(dir, part)
+			base = joinRel(base, part)
+		}
+	}
+	w.top = top
+
+	rel, _ := filepath.Rel(top, absRoot)
+	return w.dir(root, absRoot, strings.Join(splitRel(rel), "/"), rules)
+}
+
+// Files returns the files under roots accepted by keep (nil keeps every
+// file), in walk order.
+func Files(roots []string, opts Options, keep func(path string) bool) ([]string, error) {
+	var files []string
+	for _, root := range roots {
+		err := Walk(root, opts, func(path string, _ fs.DirEntry) error {
+			if keep == nil || keep(path) {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+type walker struct {
+	opts        Options
+	fn          Func
+	absRoot     string
+	top         string
+	excludeBase string
+	visited     map[string]bool
+}
+
+// dir visits the directory at path, whose absolute path is abs and whose
+// slash-separated path relative to the top directory is rel.
+func (w *walker) dir(path, abs, rel string, rules ignoreRules) error {
+	if w.opts.Symlinks == SymlinkFollow {
+		real, err := filepath.EvalSymlinks(abs)
+		if err != nil {
+			return err
+		}
+		if w.visited[real] {
+			return nil
+		}
+		w.visited[real] = true
+	}
+
+	if !w.opts.NoIgnoreFiles {
+		rules = rules.load(abs, rel)
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	for _, d := range entries {
+		name := d.Name()
+		childPath := filepath.Join(path, name)
+		childAbs := filepath.Join(abs, name)
+		childRel := joinRel(rel, name)
+
+		isDir := d.IsDir()
+		if d.Type()&fs.ModeSymlink != 0 {
+			if w.opts.Symlinks == SymlinkSkip {
+				continue
+			}
+			target, err := os.Stat(childPath)
+			if err != nil {
+				continue // dangling link
+			}
+			if target.IsDir() && w.opts.Symlinks != SymlinkFollow {
+				continue
+			}
+			isDir = target.IsDir()
+		}
+
+		if isDir && name == ".git" {
+			continue
+		}
+		if rules.ignored(childRel, isDir) || w.excluded(childAbs, isDir) {
+			continue
+		}
+
+		if isDir {
+			if err := w.dir(childPath, childAbs, childRel, rules); err != nil {
+				return err
+			}
+			continue
+		}
+		if w.opts.Filter != nil && !w.opts.Filter(childPath) {
+			continue
+		}
+		if err := w.fn(childPath, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) excluded(abs string, isDir bool) bool {
+	patterns := w.opts.Exclude
+	if isDir && len(w.opts.ExcludeDirs) > 0 {
+		patterns = append(append([]string(nil), patterns...), w.opts.ExcludeDirs...)
+	}
+	if len(patterns) == 0 {
+		return false
+	}
+	rel, err := filepath.Rel(w.excludeBase, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		rel = abs
+	}
+	for _, pattern := range patterns {
+		if MatchGlob(strings.TrimSpace(pattern), rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// repoTop returns the nearest ancestor of dir (inclusive) containing .git,
+// or dir itself when there is none.
+func repoTop(dir string) string {
+	for current := dir; ; {
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return current
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dir
+		}
+		current = parent
+	}
+}
+
+func splitRel(rel string) []string {
+	if rel == "." || rel == "" {
+		return nil
+	}
+	return strings.Split(filepath.ToSlash(rel), "/")
+}
+
+func joinRel(base, name string) string {
+	if base == "" || base == "." {
+		return name
+	}
+	return base + "/" + name
+}
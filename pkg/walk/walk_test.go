@@ -0,0 +1,161 @@
+package walk
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+}
+
+func collect(t *testing.T, root string, opts Options) []string {
+	t.Helper()
+	var got []string
+	err := Walk(root, opts, func(path string, _ fs.DirEntry) error {
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		got = append(got, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk: %v", err)
+	}
+	return got
+}
+
+func TestWalkHonoursIgnoreFiles(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		".git/HEAD":               "ref",
+		".gitignore":              "*.log\nbuild/\n!keep.log\n",
+		".lintkitignore":          "/fixtures\n",
+		"a.md":                    "",
+		"debug.log":               "",
+		"keep.log":                "",
+		"build/out.md":            "",
+		"fixtures/x.md":           "",
+		"docs/fixtures/y.md":      "",
+		"docs/.gitignore":         "draft-*.md\n",
+		"docs/draft-1.md":         "",
+		"docs/final.md":           "",
+		"node_modules/pkg/a.md":   "",
+		"docs/sub/draft-2.md":     "",
+		"docs/sub/nested/deep.md": "",
+	})
+
+	got := collect(t, root, Options{Exclude: []string{"node_modules/**", "**/nested/**"}})
+	want := []string{
+		".gitignore", ".lintkitignore", "a.md", "docs/.gitignore",
+		"docs/final.md", "docs/fixtures/y.md", "keep.log",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v\nwant %v", got, want)
+	}
+
+	// Walking a subdirectory still applies the ignore files above it.
+	got = collect(t, filepath.Join(root, "docs"), Options{})
+	want = []string{".gitignore", "final.md", "fixtures/y.md", "sub/nested/deep.md"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("subdir: got %v\nwant %v", got, want)
+	}
+}
+
+func TestWalkSymlinkPolicy(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"real/a.md": "", "b.md": ""})
+	if err := os.Symlink(filepath.Join(root, "real"), filepath.Join(root, "linkdir")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(root, "b.md"), filepath.Join(root, "c.md")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+	if err := os.Symlink(root, filepath.Join(root, "real", "loop")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+
+	tests := []struct {
+		policy Symlinks
+		want   []string
+	}{
+		{SymlinkFiles, []string{"b.md", "c.md", "real/a.md"}},
+		{SymlinkSkip, []string{"b.md", "real/a.md"}},
+		{SymlinkFollow, []string{"b.md", "c.md", "linkdir/a.md"}},
+	}
+	for _, tc := range tests {
+		got := collect(t, root, Options{Symlinks: tc.policy, NoIgnoreFiles: true})
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("policy %d: got %v, want %v", tc.policy, got, tc.want)
+		}
+	}
+}
+
+func TestWalkFilter(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"a.md": "", "b.md": "", "sub/c.md": ""})
+
+	keep := func(path string) bool { return filepath.Base(path) != "b.md" }
+	got := collect(t, root, Options{Filter: keep})
+	if want := []string{"a.md", "sub/c.md"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	file := filepath.Join(root, "b.md")
+	if got := collect(t, file, Options{Filter: keep}); len(got) != 0 {
+		t.Fatalf("expected filtered file root to be skipped, got %v", got)
+	}
+}
+
+func TestWalkExcludeDirs(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		".golangci.yml":    "",
+		".github/ci.yml":   "",
+		"a.go":             "",
+		"sub/.env.example": "",
+		"sub/.cache/x.go":  "",
+		"vendor/v.go":      "",
+	})
+
+	got := collect(t, root, Options{ExcludeDirs: []string{".*", "vendor"}, NoIgnoreFiles: true})
+	if want := []string{".golangci.yml", "a.go", "sub/.env.example"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"**/testdata/**", "pkg/wikifmt/testdata/wiki/good.md", true},
+		{"**/testdata/**", "testdata/a.md", true},
+		{"**/testdata/**", "pkg/data/a.md", false},
+		{"docs/*.md", "docs/a.md", true},
+		{"docs/*.md", "docs/sub/a.md", false},
+		{"docs/**/*.md", "docs/a.md", true},
+		{"*.bak", "deep/nested/file.bak", true},
+		{"./vendor/**", "vendor/x/y.go", true},
+	}
+
+	for _, tc := range tests {
+		if got := MatchGlob(tc.pattern, tc.path); got != tc.want {
+			t.Fatalf("MatchGlob(%q, %q) = %v, want %v", tc.pattern, tc.path, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Package watch detects file changes by polling directory trees. Polling
+// needs no platform support and honours the same ignore rules as the
+// linters, at the cost of a walk per interval.
+package watch
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"sort"
+	"time"
+
+	"github.com/dkoosis/lintkit/pkg/walk"
+)
+
+// Defaults for Watch.
+const (
+	DefaultInterval = 500 * time.Millisecond
+	DefaultDebounce = 300 * time.Millisecond
+)
+
+// fileState is what a poll compares to decide whether a file changed.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Poller remembers the files below a set of roots and reports which were
+// added, modified, or removed since the previous poll.
+type Poller struct {
+	roots []string
+	opts  walk.Options
+	state map[string]fileState
+}
+
+// NewPoller records the current state of the files below roots.
+func NewPoller(roots []string, opts walk.Options) (*Poller, error) {
+	p := &Poller{roots: roots, opts: opts}
+	state, err := p.scan()
+	if err != nil {
+		return nil, err
+	}
+	p.state = state
+	return p, nil
+}
+
+// Poll returns the paths that changed since the previous poll in lexical
+// order.
+func (p *Poller) Poll() ([]string, error) {
+	state, err := p.scan()
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	for path, s := range state {
+		if old, ok := p.state[path]; !ok || !old.modTime.Equal(s.modTime) || old.size != s.size {
+			changed = append(changed, path)
+		}
+	}
+	for path := range p.state {
+		if _, ok := state[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	p.state = state
+	sort.Strings(changed)
+	return changed, nil
+}
+
+func (p *Poller) scan() (map[string]fileState, error) {
+	state := map[string]fileState{}
+	for _, root := range p.roots {
+		err := walk.Walk(root, p.opts, func(path string, d fs.DirEntry) error {
+			info, err := d.Info()
+			if err != nil {
+				return nil // removed while walking
+			}
+			state[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+		if errors.Is(err, fs.ErrNotExist) {
+			continue // a root that does not exist yet, or was removed
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return state, nil
+}
+
+// Watch polls p every interval and calls fn with the changed paths once
+// no further change has been seen for debounce, so a burst of saves
+// triggers one call. It returns fn's first error, or ctx's error when ctx
+// is done.
+func Watch(ctx context.Context, p *Poller, interval, debounce time.Duration, fn func(changed []string) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	pending := map[string]bool{}
+	var last time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			changed, err := p.Poll()
+			if err != nil {
+				return err
+			}
+			for _, path := range changed {
+				pending[path] = true
+			}
+			if len(changed) > 0 {
+				last = now
+				continue
+			}
+			if len(pending) == 0 || now.Sub(last) < debounce {
+				continue
+			}
+			batch := make([]string, 0, len(pending))
+			for path := range pending {
+				batch = append(batch, path)
+			}
+			sort.Strings(batch)
+			pending = map[string]bool{}
+			if err := fn(batch); err != nil {
+				return err
+			}
+		}
+	}
+}
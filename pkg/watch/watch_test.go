@@ -0,0 +1,101 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dkoosis/lintkit/pkg/walk"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestPollerReportsChanges(t *testing.T) {
+	root := t.TempDir()
+	a, b, c := filepath.Join(root, "a.md"), filepath.Join(root, "b.md"), filepath.Join(root, "c.md")
+	writeFile(t, a, "a")
+	writeFile(t, b, "b")
+
+	p, err := NewPoller([]string{root}, walk.Options{})
+	if err != nil {
+		t.Fatalf("NewPoller: %v", err)
+	}
+	if changed, err := p.Poll(); err != nil || len(changed) != 0 {
+		t.Fatalf("expected no changes, got %v (%v)", changed, err)
+	}
+
+	writeFile(t, a, "longer")
+	if err := os.Remove(b); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	writeFile(t, c, "c")
+
+	changed, err := p.Poll()
+	if err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	if want := []string{a, b, c}; !reflect.DeepEqual(changed, want) {
+		t.Fatalf("expected %v, got %v", want, changed)
+	}
+}
+
+func TestPollerWatchesFileRootsThatComeAndGo(t *testing.T) {
+	root := t.TempDir()
+	schema := filepath.Join(root, "schema.json")
+
+	p, err := NewPoller([]string{schema}, walk.Options{})
+	if err != nil {
+		t.Fatalf("NewPoller with a missing root: %v", err)
+	}
+	writeFile(t, schema, "{}")
+	writeFile(t, filepath.Join(root, "other.json"), "{}")
+	if changed, err := p.Poll(); err != nil || !reflect.DeepEqual(changed, []string{schema}) {
+		t.Fatalf("expected only %s to change, got %v (%v)", schema, changed, err)
+	}
+	if err := os.Remove(schema); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if changed, err := p.Poll(); err != nil || !reflect.DeepEqual(changed, []string{schema}) {
+		t.Fatalf("expected removal of %s, got %v (%v)", schema, changed, err)
+	}
+}
+
+func TestWatchDebouncesBursts(t *testing.T) {
+	root := t.TempDir()
+	p, err := NewPoller([]string{root}, walk.Options{})
+	if err != nil {
+		t.Fatalf("NewPoller: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go func() {
+		for _, name := range []string{"a.md", "b.md"} {
+			writeFile(t, filepath.Join(root, name), name)
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	var batches [][]string
+	stop := errors.New("stop")
+	err = Watch(ctx, p, 10*time.Millisecond, 100*time.Millisecond, func(changed []string) error {
+		batches = append(batches, changed)
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected callback error, got %v", err)
+	}
+	if len(batches) != 1 || len(batches[0]) != 2 {
+		t.Fatalf("expected one batch of two files, got %v", batches)
+	}
+}
@@ -0,0 +1,56 @@
+package wikifmt
+
+import "github.com/dkoosis/lintkit/pkg/sarif"
+
+// ToolName is the SARIF driver name for wikifmt.
+const ToolName = "lintkit-wikifmt"
+
+var rules = []sarif.ReportingDescriptor{
+	{
+		ID:                   "wiki-frontmatter-yaml",
+		ShortDescription:     sarif.Text("Frontmatter is missing or not valid YAML"),
+		FullDescription:      sarif.Text("Every wiki page must start with a '---' delimited YAML frontmatter block: a mapping with no duplicate keys, where title and date are single values and tags is a tag or a list of tags."),
+		Help:                 sarif.Text("Add or repair the frontmatter block at the top of the file."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "error"},
+	},
+	{
+		ID:                   "wiki-frontmatter-required",
+		ShortDescription:     sarif.Text("Required frontmatter key is missing"),
+		FullDescription:      sarif.Text("Wiki pages must declare title, date, and tags in their frontmatter."),
+		Help:                 sarif.Text("Add the missing key to the frontmatter block."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "error"},
+	},
+	{
+		ID:                   "wiki-date-format",
+		ShortDescription:     sarif.Text("Frontmatter date is not YYYY-MM-DD"),
+		FullDescription:      sarif.Text("The frontmatter date must be an ISO 8601 calendar date such as 2024-01-31."),
+		Help:                 sarif.Text("Rewrite the date value as YYYY-MM-DD."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "error"},
+	},
+	{
+		ID:                   "wiki-link-broken",
+		ShortDescription:     sarif.Text("Wikilink or markdown link does not resolve"),
+		FullDescription:      sarif.Text("A [[wikilink]] or relative markdown link points at a page that does not exist under the scanned roots. Targets match by file name, case-insensitively or by slug."),
+		Help:                 sarif.Text("Fix the link target or create the missing page."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "error"},
+	},
+	{
+		ID:                   "wiki-tag-case-variant",
+		ShortDescription:     sarif.Text("Tag is spelled with inconsistent casing"),
+		FullDescription:      sarif.Text("The same tag appears with different capitalisation across pages, which splits tag indexes."),
+		Help:                 sarif.Text("Pick one spelling for the tag and use it everywhere."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"},
+	},
+	{
+		ID:                   "wiki-tag-orphan",
+		ShortDescription:     sarif.Text("Tag is used only once"),
+		FullDescription:      sarif.Text("A tag that appears on a single page does not group anything and is often a typo of an existing tag."),
+		Help:                 sarif.Text("Reuse an existing tag or drop the one-off tag."),
+		DefaultConfiguration: &sarif.ReportingConfiguration{Level: "warning"},
+	},
+}
+
+// Rules returns the rule catalog for wikifmt.
+func Rules() []sarif.ReportingDescriptor {
+	return append([]sarif.ReportingDescriptor(nil), rules...)
+}
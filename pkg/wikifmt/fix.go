@@ -0,0 +1,125 @@
+package wikifmt
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+)
+
+// now dates the frontmatter added by fixes; tests replace it.
+var now = time.Now
+
+// placeholderTag is added by the missing-tags fix for the writer to replace.
+const placeholderTag = "untagged"
+
+// missingKeyLine returns the frontmatter line a fix adds for a missing
+// required key.
+func missingKeyLine(path, key string) string {
+	switch key {
+	case "title":
+		return "title: " + titleFromPath(path) + "\n"
+	case "date":
+		return "date: " + now().Format("2006-01-02") + "\n"
+	default:
+		return "tags:\n  - " + placeholderTag + "\n"
+	}
+}
+
+// titleFromPath derives a title from a file name: "api-guide.md" becomes
+// "Api guide".
+func titleFromPath(path string) string {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	words := strings.Fields(strings.NewReplacer("-", " ", "_", " ").Replace(base))
+	title := strings.Join(words, " ")
+	if title == "" {
+		return "Untitled"
+	}
+	return strings.ToUpper(title[:1]) + title[1:]
+}
+
+// withMissingKeyFix attaches a fix giving key a value: it replaces the
+// lines of a key written without one, and otherwise inserts the key before
+// the closing frontmatter delimiter. Keys of a flow mapping, which do not
+// start their line, get no fix.
+func withMissingKeyFix(r sarif.Result, f wikiFile, key string) sarif.Result {
+	if f.Frontmatter.End == 0 {
+		return r
+	}
+	span, ok := f.Frontmatter.Keys[key]
+	if !ok {
+		return r.WithFix("Add "+key+" to the frontmatter", sarif.Insert(f.Path, f.Frontmatter.End, missingKeyLine(f.Path, key)))
+	}
+	if span.Column != 1 {
+		return r
+	}
+	region := sarif.Region{StartLine: span.Line, StartColumn: 1, EndLine: span.EndLine + 1, EndColumn: 1}
+	return r.WithFix("Fill in "+key+" in the frontmatter", sarif.Replace(f.Path, region, missingKeyLine(f.Path, key)))
+}
+
+// withFrontmatterFix attaches a fix adding a frontmatter block with every
+// required key to a page that has none.
+func withFrontmatterFix(r sarif.Result, f wikiFile) sarif.Result {
+	block := "---\n" + missingKeyLine(f.Path, "title") + missingKeyLine(f.Path, "date") + missingKeyLine(f.Path, "tags") + "---\n"
+	return r.WithFix("Add frontmatter", sarif.Insert(f.Path, 1, block))
+}
+
+// withTagCaseFix attaches a fix respelling as canonical the tag raw that
+// starts at line and column, a character count from 1. When the text there
+// is not exactly raw, as for a quoted tag, no fix is attached.
+func withTagCaseFix(r sarif.Result, path string, lines []string, line, column int, raw, canonical string) sarif.Result {
+	if line < 1 || line > len(lines) || column < 1 {
+		return r
+	}
+	text := lines[line-1]
+	idx := byteOffset(text, column-1)
+	if idx < 0 || !strings.HasPrefix(text[idx:], raw) {
+		return r
+	}
+	region := sarif.Region{
+		StartLine:   line,
+		StartColumn: sarif.Column(text, idx),
+		EndLine:     line,
+		EndColumn:   sarif.Column(text, idx+len(raw)),
+	}
+	return r.WithFix("Spell tag as "+canonical, sarif.Replace(path, region, canonical))
+}
+
+// byteOffset returns the byte offset of the character at index n of text,
+// or -1 when text is shorter.
+func byteOffset(text string, n int) int {
+	for idx := range text {
+		if n == 0 {
+			return idx
+		}
+		n--
+	}
+	if n == 0 {
+		return len(text)
+	}
+	return -1
+}
+
+// canonicalTag picks the spelling a case-variant tag is normalized to: the
+// most used one, preferring lower case and then lexical order on ties.
+func canonicalTag(counts map[string]int) string {
+	best := ""
+	for raw, n := range counts {
+		switch {
+		case best == "":
+			best = raw
+		case n != counts[best]:
+			if n > counts[best] {
+				best = raw
+			}
+		case (raw == strings.ToLower(raw)) != (best == strings.ToLower(best)):
+			if raw == strings.ToLower(raw) {
+				best = raw
+			}
+		case raw < best:
+			best = raw
+		}
+	}
+	return best
+}
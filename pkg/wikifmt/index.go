@@ -0,0 +1,94 @@
+package wikifmt
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/walk"
+)
+
+// Index holds the parsed pages below a set of roots so single pages can be
+// re-checked against the rest of the wiki as they are edited, without
+// reading the tree again. Paths are in the form the roots were given in.
+// An Index is not safe for concurrent use.
+type Index struct {
+	roots []string
+	files map[string]wikiFile
+}
+
+// NewIndex parses every page below roots.
+func NewIndex(roots []string, opts walk.Options) (*Index, error) {
+	files, err := collectFiles(roots, opts)
+	if err != nil {
+		return nil, err
+	}
+	x := &Index{roots: roots, files: make(map[string]wikiFile, len(files))}
+	for _, f := range files {
+		x.files[f.Path] = f
+	}
+	return x, nil
+}
+
+// Covers reports whether path is a markdown page below one of the roots.
+func (x *Index) Covers(path string) bool {
+	if !strings.HasSuffix(strings.ToLower(path), ".md") {
+		return false
+	}
+	for _, root := range x.roots {
+		rel, err := filepath.Rel(root, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Update replaces the content of the page at path, adding it if needed.
+func (x *Index) Update(path, content string) {
+	x.files[path] = parseContent(path, content)
+}
+
+// Reload re-reads the page at path from disk, dropping it when the file no
+// longer exists.
+func (x *Index) Reload(path string) error {
+	f, err := parseFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		delete(x.files, path)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	x.files[path] = f
+	return nil
+}
+
+// Check returns the findings for the page at path, resolving its links and
+// tags against the whole index.
+func (x *Index) Check(path string) []sarif.Result {
+	f, ok := x.files[path]
+	if !ok {
+		return nil
+	}
+	files := x.sorted()
+	results := checkFrontmatter(f)
+	for _, r := range checkTags(files) {
+		if r.PrimaryURI() == path {
+			results = append(results, r)
+		}
+	}
+	return append(results, checkLinks(f, buildIndex(files))...)
+}
+
+func (x *Index) sorted() []wikiFile {
+	files := make([]wikiFile, 0, len(x.files))
+	for _, f := range x.files {
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
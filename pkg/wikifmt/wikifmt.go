@@ -11,16 +11,34 @@ import (
 	"sort"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/walk"
+	"github.com/dkoosis/lintkit/pkg/yamlconf"
 )
 
 // Run executes the wikifmt linter against the provided root directories.
 func Run(roots []string) (*sarif.Log, error) {
-	files, err := collectFiles(roots)
+	return RunWithOptions(roots, walk.Options{})
+}
+
+// RunWithOptions is like Run but traverses the roots with the given walk
+// options.
+func RunWithOptions(roots []string, opts walk.Options) (*sarif.Log, error) {
+	files, err := collectFiles(roots, opts)
 	if err != nil {
 		return nil, err
 	}
 
+	log := sarif.NewLog()
+	log.Runs = append(log.Runs, sarif.NewRun(sarif.Driver{Name: ToolName, Rules: Rules()}, check(files)))
+
+	return log, nil
+}
+
+// check runs every check over the parsed files, resolving links among them.
+func check(files []wikiFile) []sarif.Result {
 	index := buildIndex(files)
 
 	var results []sarif.Result
@@ -39,13 +57,7 @@ func Run(roots []string) (*sarif.Log, error) {
 		results = append(results, linkResults...)
 	}
 
-	log := sarif.NewLog()
-	log.Runs = append(log.Runs, sarif.Run{
-		Tool:    sarif.Tool{Driver: sarif.Driver{Name: "lintkit-wikifmt"}},
-		Results: results,
-	})
-
-	return log, nil
+	return results
 }
 
 // wikiFile represents a parsed wiki markdown file.
@@ -63,6 +75,22 @@ type frontmatter struct {
 	Title valueNode[string]
 	Date  valueNode[string]
 	Tags  valueNode[[]string]
+	// TagLines and TagColumns hold the position of each entry of Tags;
+	// columns count characters from 1, as yaml.v3 does.
+	TagLines   []int
+	TagColumns []int
+	// Keys holds where each key is written, including keys whose value is
+	// empty, so fixes fill those in rather than adding the key again.
+	Keys map[string]keySpan
+	// End is the line of the closing "---" delimiter.
+	End int
+}
+
+// keySpan locates a frontmatter key: its line and column, and the last
+// line of its value.
+type keySpan struct {
+	Line, Column int
+	EndLine      int
 }
 
 type valueNode[T any] struct {
@@ -79,8 +107,9 @@ type link struct {
 }
 
 type tagEntry struct {
-	Value string
-	Line  int
+	Value  string
+	Line   int
+	Column int
 }
 
 var (
@@ -91,16 +120,10 @@ var (
 	errMissingFrontmatter = errors.New("missing frontmatter")
 )
 
-func collectFiles(roots []string) ([]wikiFile, error) {
+func collectFiles(roots []string, opts walk.Options) ([]wikiFile, error) {
 	var files []wikiFile
 	for _, root := range roots {
-		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
-			if err != nil {
-				return err
-			}
-			if d.IsDir() {
-				return nil
-			}
+		err := walk.Walk(root, opts, func(path string, d fs.DirEntry) error {
 			if strings.HasSuffix(strings.ToLower(d.Name()), ".md") {
 				wf, err := parseFile(path)
 				if err != nil {
@@ -124,7 +147,10 @@ func parseFile(path string) (wikiFile, error) {
 	if err != nil {
 		return wikiFile{}, err
 	}
-	content := string(data)
+	return parseContent(path, string(data)), nil
+}
+
+func parseContent(path, content string) wikiFile {
 	lines := strings.Split(content, "\n")
 
 	fm, fmErr := parseFrontmatter(content)
@@ -132,8 +158,8 @@ func parseFile(path string) (wikiFile, error) {
 
 	var tags []tagEntry
 	if fm.Tags.IsSet {
-		for _, t := range fm.Tags.Value {
-			tags = append(tags, tagEntry{Value: t, Line: fm.Tags.Line})
+		for i, t := range fm.Tags.Value {
+			tags = append(tags, tagEntry{Value: t, Line: fm.TagLines[i], Column: fm.TagColumns[i]})
 		}
 	}
 
@@ -145,7 +171,7 @@ func parseFile(path string) (wikiFile, error) {
 		FrontmatterErr: fmErr,
 		Links:          links,
 		Tags:           tags,
-	}, nil
+	}
 }
 
 func parseFrontmatter(content string) (frontmatter, error) {
@@ -154,61 +180,61 @@ func parseFrontmatter(content string) (frontmatter, error) {
 		return frontmatter{}, errMissingFrontmatter
 	}
 
-	fm := frontmatter{}
 	raw := match[1]
-	lines := strings.Split(raw, "\n")
-	seenKeys := make(map[string]int)
-	var currentKey string
-
-	for i, line := range lines {
-		lineNo := i + 2 // account for leading '---' line
-		trimmed := strings.TrimSpace(line)
-		if trimmed == "" {
-			continue
-		}
+	fm := frontmatter{End: strings.Count(raw, "\n") + 3} // '---', raw lines, '---'
+	// The block starts on line 2, after the leading '---' line.
+	root, err := yamlconf.Parse("", 2, []byte(raw))
+	if err != nil || root == nil {
+		return fm, err
+	}
+	if root.Kind != yaml.MappingNode {
+		return fm, yamlconf.Errorf("", root, "frontmatter must be a mapping of keys to values")
+	}
 
-		if strings.HasPrefix(trimmed, "-") && !strings.HasPrefix(line, "  - ") {
-			return fm, fmt.Errorf("invalid YAML list entry without key on line %d", lineNo)
+	fm.Keys = map[string]keySpan{}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		// A value ends before the next key and the closing delimiter.
+		end := max(key.Line, lastLine(value))
+		if i+2 < len(root.Content) {
+			end = min(end, root.Content[i+2].Line-1)
 		}
+		fm.Keys[key.Value] = keySpan{Line: key.Line, Column: key.Column, EndLine: min(end, fm.End-1)}
 
-		if strings.HasPrefix(line, "  - ") {
-			if currentKey != "tags" {
-				return fm, fmt.Errorf("unexpected list item on line %d", lineNo)
+		switch key.Value {
+		case "title", "date":
+			if value.Kind != yaml.ScalarNode {
+				return fm, yamlconf.Errorf("", value, "%s must be a single value", key.Value)
 			}
-			tag := strings.TrimSpace(strings.TrimPrefix(line, "  - "))
-			fm.Tags.Value = append(fm.Tags.Value, tag)
-			fm.Tags.Line = lineNo
-			fm.Tags.IsSet = true
-			continue
-		}
-
-		parts := strings.SplitN(line, ":", 2)
-		if len(parts) != 2 {
-			return fm, fmt.Errorf("invalid frontmatter line %d", lineNo)
-		}
-
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-		currentKey = key
-
-		if prev, ok := seenKeys[key]; ok {
-			return fm, fmt.Errorf("duplicate key %q on line %d (previously on line %d)", key, lineNo, prev)
-		}
-		seenKeys[key] = lineNo
-
-		switch key {
-		case "title":
-			if value != "" {
-				fm.Title = valueNode[string]{Value: value, Line: lineNo, IsSet: true}
+			if isNull(value) {
+				continue
 			}
-		case "date":
-			if value != "" {
-				fm.Date = valueNode[string]{Value: value, Line: lineNo, IsSet: true}
+			node := valueNode[string]{Value: value.Value, Line: key.Line, IsSet: true}
+			if key.Value == "title" {
+				fm.Title = node
+			} else {
+				fm.Date = node
 			}
 		case "tags":
-			fm.Tags.Line = lineNo
-			if value != "" {
-				fm.Tags.Value = append(fm.Tags.Value, value)
+			fm.Tags.Line = key.Line
+			items := []*yaml.Node{value}
+			switch value.Kind {
+			case yaml.ScalarNode:
+			case yaml.SequenceNode:
+				items = value.Content
+			default:
+				return fm, yamlconf.Errorf("", value, "tags must be a tag or a list of tags")
+			}
+			for _, item := range items {
+				if item.Kind != yaml.ScalarNode {
+					return fm, yamlconf.Errorf("", item, "tags must be a tag or a list of tags")
+				}
+				if isNull(item) {
+					continue
+				}
+				fm.Tags.Value = append(fm.Tags.Value, item.Value)
+				fm.TagLines = append(fm.TagLines, item.Line)
+				fm.TagColumns = append(fm.TagColumns, item.Column)
 				fm.Tags.IsSet = true
 			}
 		}
@@ -217,6 +243,20 @@ func parseFrontmatter(content string) (frontmatter, error) {
 	return fm, nil
 }
 
+// lastLine returns the last line n or its descendants start on.
+func lastLine(n *yaml.Node) int {
+	line := n.Line
+	for _, c := range n.Content {
+		line = max(line, lastLine(c))
+	}
+	return line
+}
+
+// isNull reports whether a scalar frontmatter value is empty.
+func isNull(n *yaml.Node) bool {
+	return n.Value == "" || n.ShortTag() == "!!null"
+}
+
 func parseLinks(lines []string) []link {
 	var links []link
 	for i, line := range lines {
@@ -255,22 +295,34 @@ func checkFrontmatter(f wikiFile) []sarif.Result {
 	var results []sarif.Result
 
 	if f.FrontmatterErr != nil {
-		results = append(results, newResult("wiki-frontmatter-yaml", "invalid frontmatter YAML: "+f.FrontmatterErr.Error(), f.Path, 1))
+		line := 1
+		var perr *yamlconf.Error
+		if errors.As(f.FrontmatterErr, &perr) {
+			line = perr.Line
+		}
+		r := newResult("wiki-frontmatter-yaml", "invalid frontmatter YAML: "+f.FrontmatterErr.Error(), f.Path, line).WithFingerprint()
 		if !errors.Is(f.FrontmatterErr, errMissingFrontmatter) {
-			return results
+			return append(results, r)
 		}
+		// The frontmatter fix adds every key, so the per-key results below
+		// carry no fixes of their own.
+		results = append(results, withFrontmatterFix(r, f))
 	}
 
+	missing := func(key string) sarif.Result {
+		r := newResult("wiki-frontmatter-required", "missing required frontmatter key: "+key, f.Path, 1).WithFingerprint(key)
+		return withMissingKeyFix(r, f, key)
+	}
 	if !f.Frontmatter.Title.IsSet {
-		results = append(results, newResult("wiki-frontmatter-required", "missing required frontmatter key: title", f.Path, 1))
+		results = append(results, missing("title"))
 	}
 	if !f.Frontmatter.Date.IsSet {
-		results = append(results, newResult("wiki-frontmatter-required", "missing required frontmatter key: date", f.Path, 1))
+		results = append(results, missing("date"))
 	} else if !datePattern.MatchString(f.Frontmatter.Date.Value) {
-		results = append(results, newResult("wiki-date-format", fmt.Sprintf("date must be YYYY-MM-DD, got %q", f.Frontmatter.Date.Value), f.Path, f.Frontmatter.Date.Line))
+		results = append(results, newResult("wiki-date-format", fmt.Sprintf("date must be YYYY-MM-DD, got %q", f.Frontmatter.Date.Value), f.Path, f.Frontmatter.Date.Line).WithFingerprint(f.Frontmatter.Date.Value))
 	}
 	if !f.Frontmatter.Tags.IsSet {
-		results = append(results, newResult("wiki-frontmatter-required", "missing required frontmatter key: tags", f.Path, 1))
+		results = append(results, missing("tags"))
 	}
 
 	return results
@@ -283,12 +335,12 @@ func checkLinks(f wikiFile, index map[string]string) []sarif.Result {
 		case "wikilink":
 			if !resolveWikiLink(l.Target, index) {
 				msg := fmt.Sprintf("broken wikilink [[%s]]", l.Target)
-				results = append(results, newResult("wiki-link-broken", msg, f.Path, l.Line))
+				results = append(results, newResult("wiki-link-broken", msg, f.Path, l.Line).WithFingerprint(l.Kind, l.Target))
 			}
 		case "markdown":
 			if !resolveMarkdownLink(f.Path, l.Target, index) {
 				msg := fmt.Sprintf("broken markdown link %s", l.Target)
-				results = append(results, newResult("wiki-link-broken", msg, f.Path, l.Line))
+				results = append(results, newResult("wiki-link-broken", msg, f.Path, l.Line).WithFingerprint(l.Kind, l.Target))
 			}
 		}
 	}
@@ -350,36 +402,70 @@ func sameFile(a, b string) bool {
 
 func checkTags(files []wikiFile) []sarif.Result {
 	type occurrence struct {
-		file string
-		line int
-		raw  string
+		file  string
+		lines []string
+		line  int
+		col   int
+		raw   string
 	}
 	tagOccurrences := make(map[string][]occurrence)
-	casing := make(map[string]map[string]struct{})
+	casing := make(map[string]map[string]int)
 
 	for _, f := range files {
 		for _, t := range f.Tags {
 			norm := strings.ToLower(t.Value)
-			tagOccurrences[norm] = append(tagOccurrences[norm], occurrence{file: f.Path, line: t.Line, raw: t.Value})
+			tagOccurrences[norm] = append(tagOccurrences[norm], occurrence{file: f.Path, lines: f.Lines, line: t.Line, col: t.Column, raw: t.Value})
 			if _, ok := casing[norm]; !ok {
-				casing[norm] = make(map[string]struct{})
+				casing[norm] = make(map[string]int)
 			}
-			casing[norm][t.Value] = struct{}{}
+			casing[norm][t.Value]++
 		}
 	}
 
+	norms := make([]string, 0, len(tagOccurrences))
+	for norm := range tagOccurrences {
+		norms = append(norms, norm)
+	}
+	sort.Strings(norms)
+
 	var results []sarif.Result
 
-	for norm, occs := range tagOccurrences {
+	for _, norm := range norms {
+		occs := tagOccurrences[norm]
 		if len(occs) == 1 {
 			occ := occs[0]
 			msg := fmt.Sprintf("tag %q is only used once", occ.raw)
-			results = append(results, newResult("wiki-tag-orphan", msg, occ.file, occ.line))
+			results = append(results, newResult("wiki-tag-orphan", msg, occ.file, occ.line).WithFingerprint(occ.raw))
 		}
 		if len(casing[norm]) > 1 {
+			canonical := canonicalTag(casing[norm])
+			// The first use of each casing, which every other casing's
+			// results point to.
+			var firsts []occurrence
+			seen := map[string]bool{}
 			for _, occ := range occs {
-				msg := fmt.Sprintf("tag %q has case variants; prefer consistent casing for %q", occ.raw, norm)
-				results = append(results, newResult("wiki-tag-case-variant", msg, occ.file, occ.line))
+				if !seen[occ.raw] {
+					seen[occ.raw] = true
+					firsts = append(firsts, occ)
+				}
+			}
+			for _, occ := range occs {
+				r := newResult("wiki-tag-case-variant", "", occ.file, occ.line)
+				r.Message = sarif.Message{
+					Text:      `tag "{0}" has case variants; prefer consistent casing for "{1}"`,
+					Arguments: []string{occ.raw, norm},
+				}
+				for _, other := range firsts {
+					if other.raw != occ.raw {
+						text := fmt.Sprintf("variant %q, used %d time(s)", other.raw, casing[norm][other.raw])
+						r.RelatedLocations = append(r.RelatedLocations, sarif.Related(len(r.RelatedLocations)+1, other.file, other.line, text))
+					}
+				}
+				r = r.WithFingerprint(occ.raw)
+				if occ.raw != canonical {
+					r = withTagCaseFix(r, occ.file, occ.lines, occ.line, occ.col, occ.raw, canonical)
+				}
+				results = append(results, r)
 			}
 		}
 	}
@@ -401,12 +487,10 @@ func newResult(ruleID, msg, path string, line int) sarif.Result {
 }
 
 func levelForRule(rule string) string {
-	switch rule {
-	case "wiki-frontmatter-yaml", "wiki-frontmatter-required", "wiki-date-format", "wiki-link-broken":
-		return "error"
-	case "wiki-tag-case-variant", "wiki-tag-orphan":
-		return "warning"
-	default:
-		return "note"
+	for _, r := range rules {
+		if r.ID == rule && r.DefaultConfiguration != nil {
+			return r.DefaultConfiguration.Level
+		}
 	}
+	return "note"
 }
@@ -1,9 +1,16 @@
 package wikifmt
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/dkoosis/lintkit/pkg/fix"
 	"github.com/dkoosis/lintkit/pkg/sarif"
+	"github.com/dkoosis/lintkit/pkg/walk"
 )
 
 func TestRunProducesSarif(t *testing.T) {
@@ -28,6 +35,17 @@ func TestRunProducesSarif(t *testing.T) {
 	if countCaseVariant < 2 {
 		t.Fatalf("expected case variant warnings for both files, got %d", countCaseVariant)
 	}
+	for _, r := range results {
+		if r.RuleID != "wiki-tag-case-variant" {
+			continue
+		}
+		if len(r.RelatedLocations) == 0 || r.RelatedLocations[0].Message == nil {
+			t.Fatalf("expected case variant to point at the other casing, got %+v", r)
+		}
+		if related := r.RelatedLocations[0].Message.Text; strings.Contains(related, fmt.Sprintf("%q", r.Message.Arguments[0])) {
+			t.Fatalf("related location %q repeats the result's own casing", related)
+		}
+	}
 }
 
 func assertHasResult(t *testing.T, results []sarif.Result, rule, path string) {
@@ -58,3 +76,244 @@ func countRuleForPath(results []sarif.Result, rule, path string) int {
 	}
 	return count
 }
+
+func TestRunDescribesRules(t *testing.T) {
+	log, err := Run([]string{"testdata/wiki"})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) == 0 {
+		t.Fatalf("expected driver rules to be populated")
+	}
+	for _, r := range run.Results {
+		if r.RuleIndex == nil {
+			t.Fatalf("result %s has no ruleIndex", r.RuleID)
+		}
+		if got := run.Tool.Driver.Rules[*r.RuleIndex].ID; got != r.RuleID {
+			t.Fatalf("ruleIndex for %s points at %s", r.RuleID, got)
+		}
+	}
+}
+
+func TestRunFingerprintsAreStable(t *testing.T) {
+	first, err := Run([]string{"testdata/wiki"})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	second, err := Run([]string{"testdata/wiki"})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	seen := map[string]bool{}
+	for _, r := range first.Runs[0].Results {
+		fp := r.PartialFingerprints[sarif.FingerprintKey]
+		if fp == "" {
+			t.Fatalf("result %s has no fingerprint", r.RuleID)
+		}
+		seen[fp] = true
+	}
+	for _, r := range second.Runs[0].Results {
+		if !seen[r.PartialFingerprints[sarif.FingerprintKey]] {
+			t.Fatalf("fingerprint for %s changed between runs", r.RuleID)
+		}
+	}
+}
+
+func TestParseFrontmatterUsesYAML(t *testing.T) {
+	fm, err := parseFrontmatter("---\ntitle: \"Quoted: title\"\ndate: 2024-01-31\ntags: [api, ops]\nextra:\n  nested: ok\n---\n")
+	if err != nil {
+		t.Fatalf("parseFrontmatter: %v", err)
+	}
+	if fm.Title.Value != "Quoted: title" || fm.Date.Line != 3 || fm.End != 7 {
+		t.Fatalf("unexpected frontmatter: %+v", fm)
+	}
+	if strings.Join(fm.Tags.Value, ",") != "api,ops" || fm.TagLines[1] != 4 {
+		t.Fatalf("unexpected tags: %v on lines %v", fm.Tags.Value, fm.TagLines)
+	}
+
+	_, err = parseFrontmatter("---\ntitle: a\ntags:\n  - x\ntitle: b\n---\n")
+	if err == nil || err.Error() != `line 5, column 1: duplicate key "title" (first defined on line 2)` {
+		t.Fatalf("expected duplicate key error, got %v", err)
+	}
+}
+
+func TestIndexRechecksEditedPage(t *testing.T) {
+	idx, err := NewIndex([]string{"testdata/wiki"}, walk.Options{})
+	if err != nil {
+		t.Fatalf("NewIndex: %v", err)
+	}
+	path := "testdata/wiki/broken-links.md"
+	if !idx.Covers(path) || idx.Covers("README.md") {
+		t.Fatalf("unexpected Covers result")
+	}
+	if countRuleForPath(idx.Check(path), "wiki-link-broken", path) == 0 {
+		t.Fatalf("expected broken link before edit")
+	}
+
+	idx.Update(path, "---\ntitle: Fixed\ndate: 2024-01-01\ntags: [api]\n---\nSee [[good]].\n")
+	if n := countRuleForPath(idx.Check(path), "wiki-link-broken", path); n != 0 {
+		t.Fatalf("expected no broken links after edit, got %d", n)
+	}
+
+	if err := idx.Reload(path); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if countRuleForPath(idx.Check(path), "wiki-link-broken", path) == 0 {
+		t.Fatalf("expected broken link after reload from disk")
+	}
+}
+
+func TestFixesResolveFindings(t *testing.T) {
+	now = func() time.Time { return time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	dir := t.TempDir()
+	pages := map[string]string{
+		"api-guide.md": "---\ntitle: Guide\ndate: 2024-01-01\ntags:\n  - API\n---\nbody\n",
+		"one.md":       "---\ntitle: One\ndate: 2024-01-01\ntags:\n  - api\n---\nbody\n",
+		"two.md":       "---\ntitle: Two\ntags:\n  - api\n---\nbody\n",
+		"bare.md":      "body\n",
+	}
+	for name, content := range pages {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	log, err := Run([]string{dir})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	plan, err := fix.NewPlan(log)
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	if len(plan.Skipped) != 0 {
+		t.Fatalf("unexpected skipped fixes: %v", plan.Skipped)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	want := map[string]string{
+		"api-guide.md": "---\ntitle: Guide\ndate: 2024-01-01\ntags:\n  - api\n---\nbody\n",
+		"two.md":       "---\ntitle: Two\ntags:\n  - api\ndate: 2024-03-01\n---\nbody\n",
+		"bare.md":      "---\ntitle: Bare\ndate: 2024-03-01\ntags:\n  - untagged\n---\nbody\n",
+	}
+	for name, content := range want {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if string(data) != content {
+			t.Fatalf("unexpected %s after fix:\n%s", name, data)
+		}
+	}
+}
+
+func TestMissingKeyFixFillsEmptyValues(t *testing.T) {
+	now = func() time.Time { return time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	dir := t.TempDir()
+	pages := map[string]string{
+		"empty.md": "---\ntitle:\ndate:\ntags: []\n---\nbody\n",
+		"tilde.md": "---\ntags:\ntitle: ~\ndate: 2024-01-01\n---\nbody\n",
+		"items.md": "---\ntitle: Items\ntags:\n  -\ndate:\n---\nbody\n",
+	}
+	for name, content := range pages {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	log, err := Run([]string{dir})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	plan, err := fix.NewPlan(log)
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	if len(plan.Skipped) != 0 {
+		t.Fatalf("unexpected skipped fixes: %v", plan.Skipped)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	want := map[string]string{
+		"empty.md": "---\ntitle: Empty\ndate: 2024-03-01\ntags:\n  - untagged\n---\nbody\n",
+		"tilde.md": "---\ntags:\n  - untagged\ntitle: Tilde\ndate: 2024-01-01\n---\nbody\n",
+		"items.md": "---\ntitle: Items\ntags:\n  - untagged\ndate: 2024-03-01\n---\nbody\n",
+	}
+	for name, content := range want {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if string(data) != content {
+			t.Fatalf("unexpected %s after fix:\n%s", name, data)
+		}
+	}
+
+	log, err = Run([]string{dir})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	for name := range pages {
+		path := filepath.Join(dir, name)
+		for _, rule := range []string{"wiki-frontmatter-yaml", "wiki-frontmatter-required"} {
+			if n := countRuleForPath(log.Runs[0].Results, rule, path); n != 0 {
+				t.Fatalf("%s: %d %s findings after fix", name, n, rule)
+			}
+		}
+	}
+}
+
+func TestTagCaseFixTargetsTheTagInFlowLists(t *testing.T) {
+	dir := t.TempDir()
+	page := func(tags string) string {
+		return "---\ntitle: T\ndate: 2024-01-01\ntags: " + tags + "\n---\nbody\n"
+	}
+	pages := map[string]string{
+		"a.md":      page("[Go]"),
+		"b.md":      page("[Go, mongo]"),
+		"c.md":      page("[Go]"),
+		"flow.md":   page("[go, mongo]"),
+		"quoted.md": page(`["go"]`),
+	}
+	for name, content := range pages {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	log, err := Run([]string{dir})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	plan, err := fix.NewPlan(log)
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"flow.md":   page("[Go, mongo]"),
+		"quoted.md": page(`["go"]`), // no fix for quoted tags
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if string(data) != want {
+			t.Fatalf("unexpected %s after fix:\n%s", name, data)
+		}
+	}
+}
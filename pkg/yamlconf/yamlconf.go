@@ -0,0 +1,346 @@
+// Package yamlconf decodes YAML configuration strictly. Unknown keys,
+// duplicate keys, and values of the wrong type are errors that point at
+// the offending line and column, and every problem in a document is
+// reported at once.
+//
+// Decoding follows gopkg.in/yaml.v3, so the full YAML syntax is
+// available, including block scalars for multi-line values:
+//
+//	checks:
+//	  - name: orphans
+//	    query: |
+//	      SELECT COUNT(*)
+//	      FROM nodes
+//	      WHERE parent IS NULL
+package yamlconf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Error is a problem at a position in a YAML document.
+type Error struct {
+	File   string // empty for YAML that does not come from a file of its own
+	Line   int
+	Column int // 0 when unknown
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.File != "" && e.Column > 0:
+		return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Msg)
+	case e.File != "":
+		return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+	case e.Column > 0:
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+	default:
+		return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+	}
+}
+
+// Errorf returns an Error at n, for callers that check decoded values.
+func Errorf(file string, n *yaml.Node, format string, args ...any) *Error {
+	return &Error{File: file, Line: n.Line, Column: n.Column, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Load reads the file at path and decodes it into v; see Decode.
+func Load(path string, v any) error {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is a user-supplied config file
+	if err != nil {
+		return err
+	}
+	return Decode(path, data, v)
+}
+
+// Decode decodes the YAML document in data into v, which must be a
+// pointer. file names the document in errors. An empty document leaves v
+// unchanged.
+func Decode(file string, data []byte, v any) error {
+	n, err := Parse(file, 1, data)
+	if err != nil || n == nil {
+		return err
+	}
+	return DecodeNode(file, n, v)
+}
+
+// Parse parses the YAML document in data, which starts on the given line
+// of file, as for frontmatter embedded in a markdown file. The returned
+// node's positions are lines of file. It returns nil for an empty
+// document.
+func Parse(file string, line int, data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, syntaxError(file, line, err)
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		return nil, nil
+	}
+	n := doc.Content[0]
+	shift(n, line-1, map[*yaml.Node]bool{})
+
+	var errs []error
+	checkDuplicates(file, n, &errs)
+	return n, errors.Join(errs...)
+}
+
+// DecodeNode decodes n into v, which must be a pointer, after checking it
+// against v's type. Struct fields are matched by their yaml tags, or
+// lowercased names, as yaml.v3 does; yaml.Node fields and types with their
+// own UnmarshalYAML are taken as they are.
+func DecodeNode(file string, n *yaml.Node, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("yamlconf: decode into non-pointer %T", v)
+	}
+	var errs []error
+	check(file, n, rv.Type().Elem(), &errs)
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if err := n.Decode(v); err != nil {
+		return decodeError(file, n, err)
+	}
+	return nil
+}
+
+var (
+	nodeType        = reflect.TypeOf(yaml.Node{})
+	unmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+)
+
+// check appends an error for every key of n that t does not have and
+// every value whose shape or type does not fit.
+func check(file string, n *yaml.Node, t reflect.Type, errs *[]error) {
+	if n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	if n.Kind == yaml.ScalarNode && n.ShortTag() == "!!null" {
+		return
+	}
+	for {
+		if t == nodeType || t.Implements(unmarshalerType) || reflect.PointerTo(t).Implements(unmarshalerType) {
+			return
+		}
+		if t.Kind() != reflect.Pointer {
+			break
+		}
+		t = t.Elem()
+	}
+
+	fail := func(at *yaml.Node, format string, args ...any) {
+		*errs = append(*errs, Errorf(file, at, format, args...))
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+	case reflect.Struct:
+		if n.Kind != yaml.MappingNode {
+			fail(n, "expected a mapping, got %s", describe(n))
+			return
+		}
+		fields, rest := structFields(t)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, val := n.Content[i], n.Content[i+1]
+			if ft, ok := fields[key.Value]; ok {
+				check(file, val, ft, errs)
+				continue
+			}
+			if rest != nil {
+				check(file, val, rest.Elem(), errs)
+				continue
+			}
+			fail(key, "unknown key %q (want %s)", key.Value, knownKeys(fields))
+		}
+	case reflect.Map:
+		if n.Kind != yaml.MappingNode {
+			fail(n, "expected a mapping, got %s", describe(n))
+			return
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			check(file, n.Content[i], t.Key(), errs)
+			check(file, n.Content[i+1], t.Elem(), errs)
+		}
+	case reflect.Slice, reflect.Array:
+		if n.Kind != yaml.SequenceNode {
+			fail(n, "expected a list, got %s", describe(n))
+			return
+		}
+		for _, item := range n.Content {
+			check(file, item, t.Elem(), errs)
+		}
+	default:
+		if n.Kind != yaml.ScalarNode {
+			fail(n, "expected %s, got %s", typeName(t), describe(n))
+			return
+		}
+		if err := n.Decode(reflect.New(t).Interface()); err != nil {
+			fail(n, "cannot use %s as %s", describe(n), typeName(t))
+		}
+	}
+}
+
+// structFields returns the YAML keys of t's fields, flattening inline
+// structs, and the type of its inline map, if any.
+func structFields(t reflect.Type) (map[string]reflect.Type, reflect.Type) {
+	fields := map[string]reflect.Type{}
+	var rest reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue // unexported
+		}
+		tag := f.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if strings.Contains(","+opts+",", ",inline,") {
+			ft := f.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			switch ft.Kind() {
+			case reflect.Struct:
+				inner, innerRest := structFields(ft)
+				for k, v := range inner {
+					fields[k] = v
+				}
+				if innerRest != nil {
+					rest = innerRest
+				}
+			case reflect.Map:
+				rest = ft
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue // unexported embedded type without inline
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
+	}
+	return fields, rest
+}
+
+func knownKeys(fields map[string]reflect.Type) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return "no keys"
+	}
+	sort.Strings(keys)
+	return "one of " + strings.Join(keys, ", ")
+}
+
+// checkDuplicates appends an error for every mapping key defined twice.
+func checkDuplicates(file string, n *yaml.Node, errs *[]error) {
+	if n.Kind == yaml.MappingNode {
+		seen := map[string]*yaml.Node{}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key := n.Content[i]
+			if key.Kind != yaml.ScalarNode || key.Value == "<<" {
+				continue
+			}
+			if prev, ok := seen[key.Value]; ok {
+				*errs = append(*errs, Errorf(file, key, "duplicate key %q (first defined on line %d)", key.Value, prev.Line))
+				continue
+			}
+			seen[key.Value] = key
+		}
+	}
+	for _, c := range n.Content {
+		checkDuplicates(file, c, errs)
+	}
+}
+
+// shift moves the positions of n and its descendants down by lines.
+func shift(n *yaml.Node, lines int, seen map[*yaml.Node]bool) {
+	if lines == 0 || seen[n] {
+		return
+	}
+	seen[n] = true
+	n.Line += lines
+	for _, c := range n.Content {
+		shift(c, lines, seen)
+	}
+}
+
+func describe(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	default:
+		return strconv.Quote(n.Value)
+	}
+}
+
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	default:
+		return t.String()
+	}
+}
+
+var linePrefix = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// syntaxError positions a yaml.v3 parse error, whose line counts from the
+// start of the document, in file.
+func syntaxError(file string, line int, err error) error {
+	if m := linePrefix.FindStringSubmatch(err.Error()); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return &Error{File: file, Line: n + line - 1, Msg: m[2]}
+	}
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	return &Error{File: file, Line: line, Msg: msg}
+}
+
+// decodeError positions the errors yaml.v3 reports while decoding n. Its
+// type errors carry lines of file already; other errors are placed at n.
+func decodeError(file string, n *yaml.Node, err error) error {
+	var perr *Error
+	if errors.As(err, &perr) {
+		if perr.File == "" {
+			perr.File = file
+		}
+		return perr
+	}
+	var terr *yaml.TypeError
+	if !errors.As(err, &terr) {
+		return Errorf(file, n, "%v", err)
+	}
+	errs := make([]error, 0, len(terr.Errors))
+	for _, e := range terr.Errors {
+		if m := linePrefix.FindStringSubmatch(e); m != nil {
+			line, _ := strconv.Atoi(m[1])
+			errs = append(errs, &Error{File: file, Line: line, Msg: m[2]})
+			continue
+		}
+		errs = append(errs, Errorf(file, n, "%s", e))
+	}
+	return errors.Join(errs...)
+}
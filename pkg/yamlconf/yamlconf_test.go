@@ -0,0 +1,105 @@
+package yamlconf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type query struct {
+	Name  string `yaml:"name"`
+	Query string `yaml:"query"`
+	Limit int    `yaml:"limit"`
+}
+
+type inner struct {
+	Mode string `yaml:"mode"`
+}
+
+type config struct {
+	Paths  []string `yaml:"paths"`
+	Checks []query  `yaml:"checks"`
+	inner  `yaml:",inline"`
+}
+
+func TestDecodeBlockScalars(t *testing.T) {
+	data := []byte(`paths: [a, b]
+mode: fast
+checks:
+  - name: orphans
+    query: |
+      SELECT COUNT(*)
+      FROM nodes
+`)
+	var cfg config
+	if err := Decode("c.yml", data, &cfg); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if len(cfg.Paths) != 2 || cfg.Mode != "fast" || len(cfg.Checks) != 1 {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	if cfg.Checks[0].Query != "SELECT COUNT(*)\nFROM nodes\n" {
+		t.Fatalf("unexpected query: %q", cfg.Checks[0].Query)
+	}
+}
+
+func TestDecodeReportsPositions(t *testing.T) {
+	data := []byte(`paths: a
+checks:
+  - name: x
+    qurey: SELECT 1
+    limit: many
+mode: a
+mode: b
+`)
+	err := Decode("c.yml", data, &config{})
+	if err == nil {
+		t.Fatalf("expected errors")
+	}
+	for _, want := range []string{
+		`c.yml:7:1: duplicate key "mode" (first defined on line 6)`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in:\n%v", want, err)
+		}
+	}
+
+	// Duplicates are reported before decoding; fix them to see the rest.
+	data = []byte(strings.Replace(string(data), "mode: b\n", "", 1))
+	err = Decode("c.yml", data, &config{})
+	for _, want := range []string{
+		`c.yml:1:8: expected a list, got "a"`,
+		`c.yml:4:5: unknown key "qurey" (want one of limit, name, query)`,
+		`c.yml:5:12: cannot use "many" as an integer`,
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in:\n%v", want, err)
+		}
+	}
+}
+
+func TestParseShiftsLines(t *testing.T) {
+	_, err := Parse("", 2, []byte("title: a\ntitle: b\n"))
+	var perr *Error
+	if !errors.As(err, &perr) || perr.Line != 3 || perr.Column != 1 {
+		t.Fatalf("expected duplicate on line 3, got %v", err)
+	}
+	if got := perr.Error(); got != `line 3, column 1: duplicate key "title" (first defined on line 2)` {
+		t.Fatalf("unexpected message: %s", got)
+	}
+
+	_, err = Parse("f.md", 2, []byte("title: [a\n"))
+	if !errors.As(err, &perr) || perr.File != "f.md" || perr.Line < 2 {
+		t.Fatalf("expected positioned syntax error, got %v", err)
+	}
+}
+
+func TestDecodeEmptyDocument(t *testing.T) {
+	cfg := config{Paths: []string{"keep"}}
+	if err := Decode("c.yml", []byte("# nothing\n"), &cfg); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if len(cfg.Paths) != 1 {
+		t.Fatalf("empty document changed config: %+v", cfg)
+	}
+}